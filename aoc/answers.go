@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"github.com/ubermensch/advent_of_code_2023/solver"
+	"io/fs"
+	"os"
+	"slices"
+)
+
+// The default location of the known-correct answers.
+const answersFile = "answers.toml"
+
+const answersHeader = `# Known-correct puzzle answers, checked by "aoc run -verify".
+#
+# Each answer applies to the input with the given SHA-256 hash (as reported
+# by "aoc run -format json"), so that answers for different puzzle inputs can
+# live side by side. Update with "aoc run -record" once an answer is known to
+# be correct.
+
+`
+
+// An answer as recorded in the answers file.
+type expectedAnswer struct {
+	Day       int    `toml:"day"`
+	Part      int    `toml:"part"`
+	InputHash string `toml:"input_hash"`
+	Answer    int    `toml:"answer"`
+}
+
+// Identifies the answer to a part of a day's puzzle for a particular input.
+type answerKey struct {
+	day       int
+	part      solver.Part
+	inputHash string
+}
+
+func keyOf(r solver.Result) answerKey {
+	return answerKey{day: r.Day, part: r.Part, inputHash: r.InputHash}
+}
+
+// The known-correct answers, keyed by day, part and input.
+type answerBook map[answerKey]int
+
+// The outcome of checking a result against the known-correct answers.
+type verdict string
+
+const (
+	pass    verdict = "pass"
+	fail    verdict = "fail"
+	unknown verdict = "unknown"
+)
+
+// Loads the answers recorded at `path`. A missing file is an empty book.
+func loadAnswers(path string) (answerBook, error) {
+	var file struct {
+		Answers []expectedAnswer `toml:"answer"`
+	}
+	if _, err := toml.DecodeFile(path, &file); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("loading answers: %w", err)
+	}
+
+	book := make(answerBook)
+	for _, a := range file.Answers {
+		key := answerKey{day: a.Day, part: solver.Part(a.Part), inputHash: a.InputHash}
+		if _, ok := book[key]; ok {
+			return nil, fmt.Errorf("loading answers: duplicate answer for day %d part %d", a.Day, a.Part)
+		}
+		book[key] = a.Answer
+	}
+	return book, nil
+}
+
+// Checks `r` against the recorded answer for its day, part and input,
+// returning the verdict and the expected answer, if any.
+func (b answerBook) check(r solver.Result) (verdict, *int) {
+	expected, ok := b[keyOf(r)]
+	if !ok {
+		return unknown, nil
+	}
+	if expected != r.Answer {
+		return fail, &expected
+	}
+	return pass, &expected
+}
+
+// Records `r` as the known-correct answer for its day, part and input.
+func (b answerBook) record(r solver.Result) {
+	b[keyOf(r)] = r.Answer
+}
+
+// Writes the answers to `path`, ordered by day and part.
+func (b answerBook) save(path string) error {
+	var answers []expectedAnswer
+	for key, answer := range b {
+		answers = append(answers, expectedAnswer{
+			Day:       key.day,
+			Part:      int(key.part),
+			InputHash: key.inputHash,
+			Answer:    answer,
+		})
+	}
+	slices.SortFunc(answers, func(a, b expectedAnswer) int {
+		return cmp.Or(
+			cmp.Compare(a.Day, b.Day),
+			cmp.Compare(a.Part, b.Part),
+			cmp.Compare(a.InputHash, b.InputHash),
+		)
+	})
+
+	buf := bytes.NewBufferString(answersHeader)
+	file := struct {
+		Answers []expectedAnswer `toml:"answer"`
+	}{answers}
+	if err := toml.NewEncoder(buf).Encode(file); err != nil {
+		return fmt.Errorf("saving answers: %w", err)
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"github.com/ubermensch/advent_of_code_2023/solver"
+	"path/filepath"
+	"testing"
+)
+
+func TestAnswerBook_check(t *testing.T) {
+	path := filepath.Join(t.TempDir(), answersFile)
+
+	// a missing file is an empty book
+	book, err := loadAnswers(path)
+	if err != nil {
+		t.Fatalf("[TestAnswerBook_check] unexpected error '%s'", err.Error())
+	}
+
+	recorded := solver.Result{Day: 1, Part: solver.One, Answer: 42, InputHash: "abc"}
+	book.record(recorded)
+	if err := book.save(path); err != nil {
+		t.Fatalf("[TestAnswerBook_check] unexpected error '%s'", err.Error())
+	}
+	book, err = loadAnswers(path)
+	if err != nil {
+		t.Fatalf("[TestAnswerBook_check] unexpected error '%s'", err.Error())
+	}
+
+	tests := []struct {
+		result solver.Result
+		want   verdict
+	}{
+		{recorded, pass},
+		{solver.Result{Day: 1, Part: solver.One, Answer: 43, InputHash: "abc"}, fail},
+		{solver.Result{Day: 1, Part: solver.Two, Answer: 42, InputHash: "abc"}, unknown},
+		{solver.Result{Day: 1, Part: solver.One, Answer: 42, InputHash: "def"}, unknown},
+	}
+	for _, test := range tests {
+		if got, _ := book.check(test.result); got != test.want {
+			t.Fatalf("[TestAnswerBook_check] for %v expected %s, actual %s", test.result, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/ubermensch/advent_of_code_2023/solver"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"text/tabwriter"
+	"time"
+)
+
+// The default location of the benchmark history.
+const historyFile = "bench_history.json"
+
+// The timings and allocations of benchmarking one part of a day's puzzle.
+type benchmark struct {
+	Time      time.Time `json:"time"`
+	Revision  string    `json:"revision,omitempty"`
+	Day       int       `json:"day"`
+	Part      int       `json:"part"`
+	InputHash string    `json:"input_hash"`
+	Runs      int       `json:"runs"`
+	// Durations of the runs, excluding reading the input.
+	Min    time.Duration `json:"min_ns"`
+	Median time.Duration `json:"median_ns"`
+	P95    time.Duration `json:"p95_ns"`
+	// Heap allocations per run.
+	Allocs uint64 `json:"allocs_per_run"`
+	Bytes  uint64 `json:"bytes_per_run"`
+}
+
+// Whether `b` and `other` benchmark the same part of a puzzle, for the
+// same input.
+func (b benchmark) sameAs(other benchmark) bool {
+	return b.Day == other.Day && b.Part == other.Part && b.InputHash == other.InputHash
+}
+
+// Returns the value at the `p`th percentile of the sorted `durations`,
+// using the nearest-rank method.
+func percentile(durations []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(durations))))
+	return durations[max(rank-1, 0)]
+}
+
+// Returns the median of the sorted `durations`.
+func median(durations []time.Duration) time.Duration {
+	mid := len(durations) / 2
+	if len(durations)%2 == 0 {
+		return (durations[mid-1] + durations[mid]) / 2
+	}
+	return durations[mid]
+}
+
+// Solves `part` of `day` against `input` `runs` times, measuring the
+// duration and allocations of every run.
+func benchPart(day int, part solver.Part, input []byte, runs int) (benchmark, error) {
+	var durations []time.Duration
+	var before, after runtime.MemStats
+	var allocs, allocBytes uint64
+	var inputHash string
+
+	for i := 0; i < runs; i++ {
+		runtime.ReadMemStats(&before)
+		results, err := solvers[day].Solve(bytes.NewReader(input), part)
+		runtime.ReadMemStats(&after)
+		if err != nil {
+			return benchmark{}, err
+		}
+
+		durations = append(durations, results[0].Duration)
+		inputHash = results[0].InputHash
+		allocs += after.Mallocs - before.Mallocs
+		allocBytes += after.TotalAlloc - before.TotalAlloc
+	}
+	slices.Sort(durations)
+
+	return benchmark{
+		Time:      time.Now().UTC(),
+		Revision:  revision(),
+		Day:       day,
+		Part:      int(part),
+		InputHash: inputHash,
+		Runs:      runs,
+		Min:       durations[0],
+		Median:    median(durations),
+		P95:       percentile(durations, 95),
+		Allocs:    allocs / uint64(runs),
+		Bytes:     allocBytes / uint64(runs),
+	}, nil
+}
+
+// Returns the VCS revision the binary was built from, if known.
+func revision() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	rev := ""
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			rev = setting.Value
+		case "vcs.modified":
+			if setting.Value == "true" && rev != "" {
+				rev += "+dirty"
+			}
+		}
+	}
+	return rev
+}
+
+// Loads the benchmark history at `path`. A missing file is an empty
+// history.
+func loadHistory(path string) ([]benchmark, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading benchmark history: %w", err)
+	}
+
+	var history []benchmark
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, fmt.Errorf("loading benchmark history: %w", err)
+	}
+	return history, nil
+}
+
+func saveHistory(path string, history []benchmark) error {
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return fmt.Errorf("saving benchmark history: %w", err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Formats the change of `b`'s median from the latest comparable run in
+// `history`.
+func formatChange(b benchmark, history []benchmark) string {
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].sameAs(b) {
+			prev := history[i].Median
+			change := 100 * float64(b.Median-prev) / float64(prev)
+			return fmt.Sprintf("%+.1f%%", change)
+		}
+	}
+	return "-"
+}
+
+func printBenchmarks(w io.Writer, benchmarks []benchmark, history []benchmark) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Day\tPart\tRuns\tMin\tMedian\tP95\tAllocs/run\tBytes/run\tvs last\t")
+
+	round := func(d time.Duration) time.Duration {
+		return d.Round(time.Microsecond)
+	}
+	for _, b := range benchmarks {
+		fmt.Fprintf(
+			tw,
+			"%d\t%d\t%d\t%s\t%s\t%s\t%d\t%d\t%s\t\n",
+			b.Day,
+			b.Part,
+			b.Runs,
+			round(b.Min),
+			round(b.Median),
+			round(b.P95),
+			b.Allocs,
+			b.Bytes,
+			formatChange(b, history),
+		)
+	}
+
+	return tw.Flush()
+}
+
+// The `bench` command: solves each selected part repeatedly, reporting
+// timing and allocation statistics and how they compare with the latest
+// recorded run, then appends them to the benchmark history.
+func bench(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	getSelection := selectionFlags(fs)
+	runs := fs.Int("n", 10, "number of times to solve each part")
+	historyPath := fs.String("history", historyFile, "path to the benchmark history")
+	save := fs.Bool("save", true, "append the results to the benchmark history")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *runs < 1 {
+		return fmt.Errorf("invalid number of runs %d", *runs)
+	}
+	sel, err := getSelection()
+	if err != nil {
+		return err
+	}
+	history, err := loadHistory(*historyPath)
+	if err != nil {
+		return err
+	}
+
+	var benchmarks []benchmark
+	for _, day := range sel.days {
+		dayInput, err := readInput(sel.resolver, day)
+		if err != nil {
+			return err
+		}
+
+		for _, part := range []solver.Part{solver.One, solver.Two} {
+			if !sel.part.Includes(part) {
+				continue
+			}
+
+			b, err := benchPart(day, part, dayInput, *runs)
+			// unimplemented parts are skipped, unless explicitly requested
+			if errors.Is(err, solver.ErrNotImplemented) && sel.part == solver.Both {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			benchmarks = append(benchmarks, b)
+		}
+	}
+
+	if err := printBenchmarks(os.Stdout, benchmarks, history); err != nil {
+		return err
+	}
+	if *save {
+		return saveHistory(*historyPath, append(history, benchmarks...))
+	}
+	return nil
+}
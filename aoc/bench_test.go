@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPercentileAndMedian(t *testing.T) {
+	var durations []time.Duration
+	for i := 1; i <= 20; i++ {
+		durations = append(durations, time.Duration(i))
+	}
+
+	if got := percentile(durations, 95); got != 19 {
+		t.Fatalf("[TestPercentileAndMedian] expected p95 of 19, actual %d", got)
+	}
+	if got := median(durations); got != 10 {
+		t.Fatalf("[TestPercentileAndMedian] expected median of 10, actual %d", got)
+	}
+	if got := median(durations[:5]); got != 3 {
+		t.Fatalf("[TestPercentileAndMedian] expected median of 3, actual %d", got)
+	}
+	if got := percentile(durations[:1], 95); got != 1 {
+		t.Fatalf("[TestPercentileAndMedian] expected p95 of 1, actual %d", got)
+	}
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"github.com/ubermensch/advent_of_code_2023/day_1/calibration"
+	"github.com/ubermensch/advent_of_code_2023/day_2/cubegame"
+	"github.com/ubermensch/advent_of_code_2023/day_3/schematic"
+	"github.com/ubermensch/advent_of_code_2023/day_4/scratchcard"
+	"github.com/ubermensch/advent_of_code_2023/day_5/almanac"
+	"github.com/ubermensch/advent_of_code_2023/day_6/boatrace"
+	"github.com/ubermensch/advent_of_code_2023/day_7/camelcards"
+	"github.com/ubermensch/advent_of_code_2023/day_8/network"
+	"github.com/ubermensch/advent_of_code_2023/day_9/predictor"
+	"github.com/ubermensch/advent_of_code_2023/solver"
+)
+
+// The registered solvers, keyed by day number.
+var solvers = map[int]solver.Solver{
+	1: calibration.Solver,
+	2: cubegame.Solver,
+	3: schematic.Solver,
+	4: scratchcard.Solver,
+	5: almanac.Solver,
+	6: boatrace.Solver,
+	7: camelcards.Solver,
+	8: network.Solver,
+	9: predictor.Solver,
+}
@@ -0,0 +1,39 @@
+// Command aoc runs the Advent of Code 2023 solvers of every day.
+//
+// Usage:
+//
+//	aoc run   [-day 1,7] [-part 2] [-input path|-] [-dir path] [-format text|json] [-verify|-record] [-answers path]
+//	aoc bench [-day 1,7] [-part 2] [-input path|-] [-dir path] [-n runs] [-history path] [-save=false]
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+const usage = `usage:
+  aoc run   [-day 1,7] [-part 2] [-input path|-] [-dir path] [-format text|json] [-verify|-record] [-answers path]
+  aoc bench [-day 1,7] [-part 2] [-input path|-] [-dir path] [-n runs] [-history path] [-save=false]`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "run":
+		err = run(os.Args[2:])
+	case "bench":
+		err = bench(os.Args[2:])
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "aoc:", err)
+		os.Exit(1)
+	}
+}
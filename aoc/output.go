@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ubermensch/advent_of_code_2023/solver"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// A result, along with the outcome of verifying it against the
+// known-correct answers (if it was).
+type report struct {
+	solver.Result
+	Verdict  verdict `json:"verdict,omitempty"`
+	Expected *int    `json:"expected,omitempty"`
+}
+
+// Writes a set of reports to `w` in a particular output format.
+type resultsPrinter func(w io.Writer, reports []report) error
+
+// The supported output formats, keyed by their -format flag value.
+var formats = map[string]resultsPrinter{
+	"text": printTable,
+	"json": printJSON,
+}
+
+// Formats stats as space separated key=value pairs, sorted by key.
+func formatStats(stats solver.Stats) string {
+	var pairs []string
+	for k, v := range stats {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", k, v))
+	}
+	slices.Sort(pairs)
+	return strings.Join(pairs, " ")
+}
+
+// Formats the outcome of verifying a report for the table.
+func formatVerdict(r report) string {
+	switch r.Verdict {
+	case pass:
+		return "ok"
+	case fail:
+		return fmt.Sprintf("FAIL (want %d)", *r.Expected)
+	case unknown:
+		return "?"
+	default:
+		return ""
+	}
+}
+
+// Prints the reports as a table, with a total of their durations.
+func printTable(w io.Writer, reports []report) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Day\tPart\tAnswer\tTime\tCheck\t  Stats")
+
+	var total time.Duration
+	for _, r := range reports {
+		fmt.Fprintf(
+			tw,
+			"%d\t%s\t%d\t%s\t%s\t  %s\n",
+			r.Day,
+			r.Part,
+			r.Answer,
+			r.Duration.Round(time.Microsecond),
+			formatVerdict(r),
+			formatStats(r.Stats),
+		)
+		total += r.Duration
+	}
+	fmt.Fprintf(tw, "\t\tTotal\t%s\t\t\n", total.Round(time.Microsecond))
+
+	return tw.Flush()
+}
+
+// Prints the reports as a JSON array, for consumption by other tools.
+func printJSON(w io.Writer, reports []report) error {
+	if reports == nil {
+		reports = []report{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(reports)
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/ubermensch/advent_of_code_2023/input"
+	"github.com/ubermensch/advent_of_code_2023/solver"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Parses a comma separated list of day numbers. An empty list, or "all",
+// selects every registered day.
+func parseDays(daysStr string) ([]int, error) {
+	var days []int
+	if daysStr == "" || daysStr == "all" {
+		for day := range solvers {
+			days = append(days, day)
+		}
+		slices.Sort(days)
+		return days, nil
+	}
+
+	for _, curr := range strings.Split(daysStr, ",") {
+		day, err := strconv.Atoi(strings.TrimSpace(curr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid day %q", curr)
+		}
+		if _, ok := solvers[day]; !ok {
+			return nil, fmt.Errorf("no solver registered for day %d", day)
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// Reads the whole input of `day`, so that it can be fed to the solver
+// without timing any I/O.
+func readInput(resolver *input.Resolver, day int) ([]byte, error) {
+	rc, err := resolver.Open(day)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
+
+// The days, part and inputs selected by the flags shared by every command.
+type selection struct {
+	days     []int
+	part     solver.Part
+	resolver *input.Resolver
+}
+
+// Registers the flags shared by every command on `fs`. The returned
+// function builds the selection once the flags have been parsed.
+func selectionFlags(fs *flag.FlagSet) func() (*selection, error) {
+	daysStr := fs.String("day", "all", "comma separated list of days to run, or \"all\"")
+	var part solver.Part
+	fs.Var(&part, "part", "part to run: 1, 2 or both")
+	inputPath := fs.String("input", "", "path to the puzzle input, or - to read stdin (only with a single -day)")
+	dir := fs.String("dir", "", "directory containing the day_N/input.txt puzzle inputs (defaults to $"+input.EnvDir+")")
+
+	return func() (*selection, error) {
+		days, err := parseDays(*daysStr)
+		if err != nil {
+			return nil, err
+		}
+		if *inputPath != "" && len(days) != 1 {
+			return nil, errors.New("-input can only be used with a single -day")
+		}
+
+		resolver := input.NewResolver(*inputPath)
+		if *dir != "" {
+			resolver.Dir = *dir
+		}
+		// stdin can only hold the input of a single day
+		if len(days) > 1 {
+			resolver.Stdin = nil
+		}
+
+		return &selection{days: days, part: part, resolver: resolver}, nil
+	}
+}
+
+// The `run` command: solves the selected days and prints their answers
+// and timings, as a table or as JSON. With -verify, answers are checked
+// against the known-correct answers, and any mismatch fails the command.
+func run(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	getSelection := selectionFlags(fs)
+	format := fs.String("format", "text", "output format: text or json")
+	verify := fs.Bool("verify", false, "check the answers against the known-correct answers, failing on any mismatch")
+	record := fs.Bool("record", false, "record the answers as the known-correct answers")
+	answersPath := fs.String("answers", answersFile, "path to the known-correct answers")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	printResults, ok := formats[*format]
+	if !ok {
+		return fmt.Errorf("invalid format %q", *format)
+	}
+	if *verify && *record {
+		return errors.New("-verify and -record cannot be used together")
+	}
+	sel, err := getSelection()
+	if err != nil {
+		return err
+	}
+
+	var results []solver.Result
+	for _, day := range sel.days {
+		dayInput, err := readInput(sel.resolver, day)
+		if err != nil {
+			return err
+		}
+
+		dayResults, err := solvers[day].Solve(bytes.NewReader(dayInput), sel.part)
+		if err != nil {
+			return err
+		}
+		results = append(results, dayResults...)
+	}
+
+	var answers answerBook
+	if *verify || *record {
+		answers, err = loadAnswers(*answersPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	failures := 0
+	reports := make([]report, 0, len(results))
+	for _, result := range results {
+		r := report{Result: result}
+		if *verify {
+			r.Verdict, r.Expected = answers.check(result)
+			if r.Verdict == fail {
+				failures++
+			}
+		}
+		if *record {
+			answers.record(result)
+		}
+		reports = append(reports, r)
+	}
+
+	if err := printResults(os.Stdout, reports); err != nil {
+		return err
+	}
+	if *record {
+		return answers.save(*answersPath)
+	}
+	if failures > 0 {
+		return fmt.Errorf("%d answer(s) do not match %s", failures, *answersPath)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Package bitset holds sets of small non-negative integers, one bit per
+// possible member, for fast membership tests and intersections.
+package bitset
+
+import (
+	"math/bits"
+	"slices"
+)
+
+// Set is a set of non-negative integers. Its memory use grows with its
+// largest member, so it suits small integers such as puzzle numbers or
+// IDs. The zero value is an empty set ready to use.
+type Set struct {
+	words []uint64
+}
+
+// Of returns the set of `members`.
+func Of(members ...int) *Set {
+	s := &Set{}
+	if len(members) > 0 {
+		s.words = make([]uint64, 0, slices.Max(members)/64+1)
+	}
+	for _, n := range members {
+		s.Add(n)
+	}
+	return s
+}
+
+// Add adds `n` to the set, returning false if it was already a member. It
+// panics if `n` is negative.
+func (s *Set) Add(n int) bool {
+	if n < 0 {
+		panic("bitset: negative member")
+	}
+	word, bit := n/64, uint64(1)<<(n%64)
+	if word >= len(s.words) {
+		s.words = append(s.words, make([]uint64, word+1-len(s.words))...)
+	}
+	if s.words[word]&bit != 0 {
+		return false
+	}
+	s.words[word] |= bit
+	return true
+}
+
+// Remove removes `n` from the set.
+func (s *Set) Remove(n int) {
+	if s.Contains(n) {
+		s.words[n/64] &^= uint64(1) << (n % 64)
+	}
+}
+
+// Contains returns whether `n` is a member of the set.
+func (s *Set) Contains(n int) bool {
+	if n < 0 || n/64 >= len(s.words) {
+		return false
+	}
+	return s.words[n/64]&(uint64(1)<<(n%64)) != 0
+}
+
+// Len returns the number of members of the set.
+func (s *Set) Len() int {
+	count := 0
+	for _, w := range s.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+// IntersectionLen returns the number of members of both sets, without
+// building their intersection.
+func (s *Set) IntersectionLen(other *Set) int {
+	count := 0
+	for i := 0; i < min(len(s.words), len(other.words)); i++ {
+		count += bits.OnesCount64(s.words[i] & other.words[i])
+	}
+	return count
+}
+
+// Intersect returns the set of members of both sets.
+func (s *Set) Intersect(other *Set) *Set {
+	words := make([]uint64, min(len(s.words), len(other.words)))
+	for i := range words {
+		words[i] = s.words[i] & other.words[i]
+	}
+	return &Set{words: words}
+}
+
+// Members returns the members of the set, in ascending order.
+func (s *Set) Members() []int {
+	var members []int
+	for i, w := range s.words {
+		for w != 0 {
+			bit := bits.TrailingZeros64(w)
+			members = append(members, i*64+bit)
+			w &= w - 1
+		}
+	}
+	return members
+}
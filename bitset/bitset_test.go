@@ -0,0 +1,140 @@
+package bitset
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	var s Set
+	if s.Contains(0) || s.Len() != 0 {
+		t.Fatalf("[TestSet] expected the zero value to be empty")
+	}
+
+	for _, n := range []int{3, 64, 0, 200, 63} {
+		if !s.Add(n) {
+			t.Fatalf("[TestSet] expected %d to be added", n)
+		}
+	}
+	if s.Add(64) {
+		t.Fatalf("[TestSet] expected 64 to already be a member")
+	}
+	if s.Len() != 5 {
+		t.Fatalf("[TestSet] expected 5 members, actual %d", s.Len())
+	}
+	if members := s.Members(); !slices.Equal(members, []int{0, 3, 63, 64, 200}) {
+		t.Fatalf("[TestSet] expected [0 3 63 64 200], actual %v", members)
+	}
+	for n, expected := range map[int]bool{-1: false, 0: true, 1: false, 63: true, 65: false, 200: true, 1000: false} {
+		if s.Contains(n) != expected {
+			t.Fatalf("[TestSet] expected Contains(%d) to be %v", n, expected)
+		}
+	}
+
+	s.Remove(63)
+	s.Remove(1000)
+	if s.Contains(63) || s.Len() != 4 {
+		t.Fatalf("[TestSet] expected 63 to be removed, actual %v", s.Members())
+	}
+}
+
+func TestSet_Add_negative(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("[TestSet_Add_negative] expected a panic")
+		}
+	}()
+	Of(-1)
+}
+
+func TestSet_Intersect(t *testing.T) {
+	rng := rand.New(rand.NewPCG(25, 2023))
+	for i := 0; i < 200; i++ {
+		a, b := make([]int, rng.IntN(30)), make([]int, rng.IntN(30))
+		for j := range a {
+			a[j] = rng.IntN(300)
+		}
+		for j := range b {
+			b[j] = rng.IntN(150)
+		}
+
+		var expected []int
+		for _, n := range a {
+			if slices.Contains(b, n) && !slices.Contains(expected, n) {
+				expected = append(expected, n)
+			}
+		}
+		slices.Sort(expected)
+
+		sa, sb := Of(a...), Of(b...)
+		if actual := sa.Intersect(sb).Members(); !slices.Equal(actual, expected) {
+			t.Fatalf("[TestSet_Intersect] for %v and %v expected %v, actual %v", a, b, expected, actual)
+		}
+		if actual := sa.IntersectionLen(sb); actual != len(expected) || sb.IntersectionLen(sa) != actual {
+			t.Fatalf("[TestSet_Intersect] for %v and %v expected %d, actual %d", a, b, len(expected), actual)
+		}
+	}
+}
+
+// Keeps the compiler from dropping the benchmarked calls.
+var sink int
+
+// Two lists of small numbers, the shape of a scratchcard's.
+func benchmarkLists() ([]int, []int) {
+	rng := rand.New(rand.NewPCG(4, 2023))
+	return rng.Perm(100)[:10], rng.Perm(100)[:25]
+}
+
+func BenchmarkOf(b *testing.B) {
+	a, _ := benchmarkLists()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Of(a...)
+	}
+}
+
+func BenchmarkSet_Contains(b *testing.B) {
+	a, other := benchmarkLists()
+	s := Of(a...)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, n := range other {
+			if s.Contains(n) {
+				sink++
+			}
+		}
+	}
+}
+
+func BenchmarkSlicesContains(b *testing.B) {
+	a, other := benchmarkLists()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, n := range other {
+			if slices.Contains(a, n) {
+				sink++
+			}
+		}
+	}
+}
+
+func BenchmarkSet_IntersectionLen(b *testing.B) {
+	a, other := benchmarkLists()
+	sa, sb := Of(a...), Of(other...)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sink += sa.IntersectionLen(sb)
+	}
+}
+
+func BenchmarkSet_Intersect(b *testing.B) {
+	a, other := benchmarkLists()
+	sa, sb := Of(a...), Of(other...)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sa.Intersect(sb)
+	}
+}
@@ -0,0 +1,75 @@
+package calibration
+
+import (
+	"context"
+	"errors"
+	"github.com/ubermensch/advent_of_code_2023/pool"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type Row string
+
+// Workers is the number of rows processed concurrently. If not positive,
+// runtime.GOMAXPROCS(0) is used.
+var Workers = 0
+
+// The spelled out digits, indexed by their value minus one.
+var digitWords = []string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine"}
+
+// Returns the digit at position `i` of `row`, and whether there is one.
+// If `spelled` is set, a word "one" through "nine" starting at `i` counts
+// as a digit too. Words may overlap (as in "twone", which holds both a 2
+// and a 1), so every position has to be looked at on its own.
+func digitAt(row Row, i int, spelled bool) (int, bool) {
+	if row[i] >= '0' && row[i] <= '9' {
+		return int(row[i] - '0'), true
+	}
+	if !spelled {
+		return 0, false
+	}
+	for value, word := range digitWords {
+		if strings.HasPrefix(string(row[i:]), word) {
+			return value + 1, true
+		}
+	}
+	return 0, false
+}
+
+// Given a Row, find the int produced by concatenating the
+// first and last digit (could be the same digit). If `spelled` is set,
+// spelled out digits count too.
+//
+// The first digit is looked for from the start of the row and the last
+// from its end, so the middle of the row is usually never read.
+func calcRow(row Row, spelled bool) (int, error) {
+	for i := 0; i < len(row); i++ {
+		first, ok := digitAt(row, i, spelled)
+		if !ok {
+			continue
+		}
+		for j := len(row) - 1; j >= i; j-- {
+			if last, ok := digitAt(row, j, spelled); ok {
+				return 10*first + last, nil
+			}
+		}
+	}
+	return 0, errors.New("no digits in row " + strconv.Quote(string(row)))
+}
+
+// Sum reads the calibration document from `r` and returns the sum of
+// the calibration values of all of its rows. If `spelled` is set, the
+// words "one" through "nine" count as digits.
+//
+// The document is streamed: rows are summed as they are read, so only
+// a batch of rows is held in memory however large the document is.
+func Sum(r io.Reader, spelled bool) (int, error) {
+	calc := func(line string) (int, error) {
+		return calcRow(Row(line), spelled)
+	}
+	add := func(sum, value int) int {
+		return sum + value
+	}
+	return pool.FoldLines(context.Background(), Workers, 0, r, calc, 0, add)
+}
@@ -0,0 +1,195 @@
+package calibration
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// The original implementation of calcRow: the digits are collected with
+// a regexp, or with spelledDigits, and the value is formatted and parsed
+// back. Kept as a reference for calcRow.
+func referenceCalcRow(row Row, spelled bool) (int, error) {
+	var digits []int
+	if spelled {
+		digits = spelledDigits(row)
+	} else {
+		re := regexp.MustCompile("[0-9]")
+		for _, i := range re.FindAllString(string(row), -1) {
+			conv, err := strconv.Atoi(i)
+			if err != nil {
+				return 0, err
+			}
+			digits = append(digits, conv)
+		}
+	}
+	if len(digits) == 0 {
+		return 0, errors.New("no digits in row " + strconv.Quote(string(row)))
+	}
+	d1, d2 := digits[0], digits[len(digits)-1]
+	return strconv.Atoi(fmt.Sprintf("%d%d", d1, d2))
+}
+
+// Returns every digit in `row`, in order, spelled or not.
+func spelledDigits(row Row) []int {
+	var digits []int
+	for i := 0; i < len(row); i++ {
+		if digit, ok := digitAt(row, i, true); ok {
+			digits = append(digits, digit)
+		}
+	}
+	return digits
+}
+
+func TestSpelledDigits(t *testing.T) {
+	rows := map[Row][]int{
+		"two1nine":         {2, 1, 9},
+		"abcone2threexyz":  {1, 2, 3},
+		"7pqrstsixteen":    {7, 6},
+		"nodigits":         nil,
+		"twone":            {2, 1},
+		"eightwo":          {8, 2},
+		"oneight":          {1, 8},
+		"sevenine":         {7, 9},
+		"threeight":        {3, 8},
+		"fiveight":         {5, 8},
+		"nineight":         {9, 8},
+		"eighthree":        {8, 3},
+		"xtwone3four":      {2, 1, 3, 4},
+		"zoneight234":      {1, 8, 2, 3, 4},
+		"eightwothree":     {8, 2, 3},
+		"oneeightwoneight": {1, 8, 2, 1, 8},
+		"0zero":            {0},
+	}
+
+	for row, expected := range rows {
+		if actual := spelledDigits(row); !slices.Equal(actual, expected) {
+			t.Fatalf("[TestSpelledDigits] for %q expected %v, actual %v", row, expected, actual)
+		}
+	}
+}
+
+func TestCalcRow(t *testing.T) {
+	rows := []Row{
+		"1abc2", "pqr3stu8vwx", "a1b2c3d4e5f", "treb7uchet", "7", "77",
+		"two1nine", "eightwothree", "abcone2threexyz", "xtwone3four",
+		"4nineeightseven2", "zoneight234", "7pqrstsixteen", "twone", "eightwo",
+		"oneight", "one", "nine9nine", "1eightwo", "twone1", "0zero0",
+		"nodigits", "", "thre", "eigh1t",
+	}
+
+	for _, row := range rows {
+		for _, spelled := range []bool{false, true} {
+			expected, expectedErr := referenceCalcRow(row, spelled)
+			actual, err := calcRow(row, spelled)
+			if (err != nil) != (expectedErr != nil) || actual != expected {
+				t.Fatalf("[TestCalcRow] for %q (spelled %v) expected %d, %v, actual %d, %v",
+					row, spelled, expected, expectedErr, actual, err)
+			}
+		}
+	}
+}
+
+// The rows of the real input, or of the examples if it is not available.
+func benchmarkRows(b *testing.B) []Row {
+	data, err := os.ReadFile("../input.txt")
+	if err != nil {
+		data, err = os.ReadFile("testdata/example_spelled.txt")
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	var rows []Row
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		rows = append(rows, Row(line))
+	}
+	return rows
+}
+
+func benchmarkCalcRow(b *testing.B, calc func(Row, bool) (int, error), spelled bool) {
+	rows := benchmarkRows(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, row := range rows {
+			if _, err := calc(row, spelled); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkCalcRow(b *testing.B) {
+	b.Run("numeric", func(b *testing.B) { benchmarkCalcRow(b, calcRow, false) })
+	b.Run("spelled", func(b *testing.B) { benchmarkCalcRow(b, calcRow, true) })
+}
+
+func BenchmarkReferenceCalcRow(b *testing.B) {
+	b.Run("numeric", func(b *testing.B) { benchmarkCalcRow(b, referenceCalcRow, false) })
+	b.Run("spelled", func(b *testing.B) { benchmarkCalcRow(b, referenceCalcRow, true) })
+}
+
+func TestSum_spelled(t *testing.T) {
+	// The last digit of each row is only found if overlapping words are
+	// handled: a left-to-right FindAll would miss the "one" in "twone" and
+	// the "two" in "eightwo".
+	doc := strings.Join([]string{"twone", "eightwo", "3oneight", "sevenine"}, "\n")
+	expected := 21 + 82 + 38 + 79
+
+	actual, err := Sum(strings.NewReader(doc), true)
+	if err != nil {
+		t.Fatalf("[TestSum_spelled] unexpected error '%s'", err.Error())
+	}
+	if actual != expected {
+		t.Fatalf("[TestSum_spelled] expected %d, actual %d", expected, actual)
+	}
+}
+
+func TestSum_noDigits(t *testing.T) {
+	_, err := Sum(strings.NewReader("a1b\nnodigits\n2"), false)
+	expected := `line 2: no digits in row "nodigits"`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("[TestSum_noDigits] expected error %q, actual %v", expected, err)
+	}
+}
+
+// A generated calibration document, produced as it is read.
+type generatedDoc struct {
+	rows, row int
+	pending   []byte
+}
+
+func (d *generatedDoc) Read(p []byte) (int, error) {
+	for len(d.pending) == 0 {
+		if d.row == d.rows {
+			return 0, io.EOF
+		}
+		// calibration value 10 * (row % 9 + 1) + 7
+		d.pending = []byte(fmt.Sprintf("ab%scd7\n", digitWords[d.row%9]))
+		d.row++
+	}
+	n := copy(p, d.pending)
+	d.pending = d.pending[n:]
+	return n, nil
+}
+
+func TestSum_stream(t *testing.T) {
+	rows := 200_000
+	expected := 0
+	for i := 0; i < rows; i++ {
+		expected += 10*(i%9+1) + 7
+	}
+
+	actual, err := Sum(&generatedDoc{rows: rows}, true)
+	if err != nil {
+		t.Fatalf("[TestSum_stream] unexpected error '%s'", err.Error())
+	}
+	if actual != expected {
+		t.Fatalf("[TestSum_stream] expected %d, actual %d", expected, actual)
+	}
+}
@@ -0,0 +1,23 @@
+package calibration
+
+import (
+	"github.com/ubermensch/advent_of_code_2023/solver"
+	"io"
+)
+
+// Solver solves the day 1 puzzle.
+var Solver = &solver.Day{
+	Number: 1,
+	Part1:  part1,
+	Part2:  part2,
+}
+
+func part1(r io.Reader) (solver.Result, error) {
+	sum, err := Sum(r, false)
+	return solver.Result{Answer: sum}, err
+}
+
+func part2(r io.Reader) (solver.Result, error) {
+	sum, err := Sum(r, true)
+	return solver.Result{Answer: sum}, err
+}
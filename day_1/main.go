@@ -1,85 +1,49 @@
 package main
 
 import (
-	"bufio"
+	"flag"
 	"fmt"
+	"github.com/ubermensch/advent_of_code_2023/day_1/calibration"
+	"github.com/ubermensch/advent_of_code_2023/input"
+	"github.com/ubermensch/advent_of_code_2023/solver"
 	"log"
-	"os"
-	"path"
-	"regexp"
-	"strconv"
 )
 
-type Row string
+var (
+	inputPath = flag.String("input", "", "path to the puzzle input, or - to read stdin")
+	part      solver.Part
+	stream    = flag.Bool("stream", false, "sum the input as it is read, without buffering it (needs a single -part)")
+)
 
-const inputFile = "/Users/frankhmeidan/golang/advent_of_code/day_1/input.txt"
+func main() {
+	flag.Var(&part, "part", "part to solve: 1, 2 or both")
+	flag.Parse()
 
-var rowChannel = make(chan int)
+	file, err := input.Open(1, *inputPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
 
-// Given a Row, find the int produced by concatenating the
-// first and last digit (could be the same digit).
-func calcRow(row Row) {
-	re := regexp.MustCompile("[0-9]")
-	var digits []int
-	for _, i := range re.FindAllString(string(row), -1) {
-		conv, err := strconv.Atoi(i)
+	// The solver buffers the input to hash it and to solve both parts,
+	// which large generated documents do not fit.
+	if *stream {
+		if part == solver.Both {
+			log.Fatal("-stream needs -part 1 or -part 2")
+		}
+		sum, err := calibration.Sum(file, part == solver.Two)
 		if err != nil {
-			log.Fatal("could not parse line " + row)
-			return
+			log.Fatal(err)
 		}
-
-		digits = append(digits, conv)
-	}
-	d1, d2 := digits[0], digits[0]
-	if len(digits) > 1 {
-		d2 = digits[len(digits)-1]
-	}
-
-	final, err := strconv.Atoi(fmt.Sprintf("%d%d", d1, d2))
-	if err != nil {
-		log.Fatal("could not parse line " + row)
+		fmt.Println(solver.Result{Day: 1, Part: part, Answer: sum})
 		return
 	}
 
-	fmt.Print(fmt.Sprintf("%d --> | ", final))
-
-	rowChannel <- final
-}
-
-func main() {
-	filePath := path.Join(inputFile)
-	file, err := os.Open(filePath)
-	if err != nil {
-		panic("could not open file")
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
+	results, err := calibration.Solver.Solve(file, part)
 	if err != nil {
-		panic("could not scan file")
-	}
-
-	var lines []Row
-	for scanner.Scan() {
-		line := scanner.Text()
-		row := Row(line)
-		lines = append(lines, row)
-	}
-	if err := scanner.Err(); err != nil {
 		log.Fatal(err)
 	}
-
-	sum := 0
-	for _, line := range lines {
-		go calcRow(line)
+	for _, result := range results {
+		fmt.Println(result)
 	}
-
-	for i := 0; i < len(lines); i++ {
-		currVal := <-rowChannel
-		fmt.Print(fmt.Sprintf("%d <-- | ", currVal))
-		sum += currVal
-
-	}
-
-	fmt.Printf("\n\nSum is: %d\n", sum)
 }
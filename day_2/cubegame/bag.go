@@ -0,0 +1,92 @@
+package cubegame
+
+import (
+	"errors"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"github.com/samber/lo"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Bag holds the number of cubes of each colour in the bag.
+type Bag map[string]int
+
+// DefaultBag returns the bag of the puzzle: 12 red, 13 green and 14 blue
+// cubes. Each call returns a new Bag, so callers are free to change it.
+func DefaultBag() Bag {
+	return Bag{"red": 12, "green": 13, "blue": 14}
+}
+
+// Colours returns the colours in the bag, sorted.
+func (b Bag) Colours() []string {
+	colours := lo.Keys(b)
+	slices.Sort(colours)
+	return colours
+}
+
+// String formats the bag as "colour=count" pairs, such as "blue=14,red=12",
+// as read by Set.
+func (b Bag) String() string {
+	return strings.Join(lo.Map(b.Colours(), func(colour string, _ int) string {
+		return colour + "=" + strconv.Itoa(b[colour])
+	}), ",")
+}
+
+// Set replaces the contents of the bag with those described by `s`, a
+// comma separated list of "colour=count" pairs, so that Bag can be used
+// with flag.Var.
+func (b *Bag) Set(s string) error {
+	bag := Bag{}
+	for _, pair := range strings.Split(s, ",") {
+		colour, countStr, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || colour == "" {
+			return fmt.Errorf("invalid bag contents %q, expected colour=count", pair)
+		}
+		count, err := strconv.Atoi(countStr)
+		if err != nil || count < 0 {
+			return fmt.Errorf("invalid count of %s cubes %q", colour, countStr)
+		}
+		if _, ok := bag[colour]; ok {
+			return fmt.Errorf("duplicate colour %q in bag", colour)
+		}
+		bag[colour] = count
+	}
+	*b = bag
+	return nil
+}
+
+// ParseBag parses bag contents in the format read by Set.
+func ParseBag(s string) (Bag, error) {
+	var bag Bag
+	err := bag.Set(s)
+	return bag, err
+}
+
+// LoadBags reads named bags from a TOML document such as:
+//
+//	[bags.puzzle]
+//	red = 12
+//	green = 13
+//	blue = 14
+func LoadBags(r io.Reader) (map[string]Bag, error) {
+	var file struct {
+		Bags map[string]Bag `toml:"bags"`
+	}
+	if _, err := toml.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("loading bags: %w", err)
+	}
+	if len(file.Bags) == 0 {
+		return nil, errors.New("loading bags: no [bags.<name>] tables")
+	}
+	for name, bag := range file.Bags {
+		for colour, count := range bag {
+			if count < 0 {
+				return nil, fmt.Errorf("loading bags: bag %q has a negative count of %s cubes", name, colour)
+			}
+		}
+	}
+	return file.Bags, nil
+}
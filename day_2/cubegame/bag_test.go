@@ -0,0 +1,90 @@
+package cubegame
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDefaultBag(t *testing.T) {
+	bag := DefaultBag()
+	bag["red"] = 1
+	bag["yellow"] = 3
+
+	if actual := DefaultBag(); !reflect.DeepEqual(actual, Bag{"red": 12, "green": 13, "blue": 14}) {
+		t.Fatalf("[TestDefaultBag] expected changes to a returned bag not to leak, actual %v", actual)
+	}
+}
+
+func TestParseBag(t *testing.T) {
+	bag, err := ParseBag("red=12, green=13,blue=14")
+	if err != nil {
+		t.Fatalf("[TestParseBag] unexpected error '%s'", err.Error())
+	}
+	if !reflect.DeepEqual(bag, DefaultBag()) {
+		t.Fatalf("[TestParseBag] expected %v, actual %v", DefaultBag(), bag)
+	}
+	if bag.String() != "blue=14,green=13,red=12" {
+		t.Fatalf("[TestParseBag] expected blue=14,green=13,red=12, actual %s", bag.String())
+	}
+
+	for _, invalid := range []string{"", "red", "red=", "=3", "red=-1", "red=x", "red=1,red=2"} {
+		if _, err := ParseBag(invalid); err == nil {
+			t.Fatalf("[TestParseBag] expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestLoadBags(t *testing.T) {
+	doc := `
+[bags.puzzle]
+red = 12
+green = 13
+blue = 14
+
+[bags.purple]
+purple = 4
+`
+	bags, err := LoadBags(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("[TestLoadBags] unexpected error '%s'", err.Error())
+	}
+	expected := map[string]Bag{"puzzle": DefaultBag(), "purple": {"purple": 4}}
+	if !reflect.DeepEqual(bags, expected) {
+		t.Fatalf("[TestLoadBags] expected %v, actual %v", expected, bags)
+	}
+
+	for _, invalid := range []string{"", "[bags.a]\nred = -1", "[bags.a]\nred = \"x\""} {
+		if _, err := LoadBags(strings.NewReader(invalid)); err == nil {
+			t.Fatalf("[TestLoadBags] expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestPossibleIdsByBag(t *testing.T) {
+	games := exampleGames(t, []string{"red", "green", "blue", "purple"})
+
+	ids := PossibleIdsByBag(games, map[string]Bag{
+		"puzzle": DefaultBag(),
+		"big":    {"red": 20, "green": 20, "blue": 20},
+		"empty":  {},
+		// a colour never drawn does not matter
+		"purple": {"red": 20, "green": 20, "blue": 20, "purple": 0},
+		// a colour missing from the bag cannot be drawn
+		"no_red": {"green": 20, "blue": 20},
+	})
+	expected := map[string][]int{
+		"puzzle": {1, 2, 5},
+		"big":    {1, 2, 3, 4, 5},
+		"empty":  {},
+		"purple": {1, 2, 3, 4, 5},
+		"no_red": {},
+	}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Fatalf("[TestPossibleIdsByBag] expected %v, actual %v", expected, ids)
+	}
+	if actual := PossibleIds(games, DefaultBag()); !slices.Equal(actual, expected["puzzle"]) {
+		t.Fatalf("[TestPossibleIdsByBag] expected %v, actual %v", expected["puzzle"], actual)
+	}
+}
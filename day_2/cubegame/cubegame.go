@@ -0,0 +1,138 @@
+package cubegame
+
+import (
+	"bufio"
+	"context"
+	"github.com/samber/lo"
+	"github.com/thoas/go-funk"
+	"github.com/ubermensch/advent_of_code_2023/pool"
+	"io"
+	"slices"
+)
+
+type Game struct {
+	id    int
+	draws []Bag
+	// The most cubes of each colour drawn at once, with every known colour
+	// present, even if never drawn.
+	drawn map[string]int
+}
+
+// Turns the game text line from the input file, found at line number
+// `lineNum`, into a Game struct and returns the pointer to it. Only
+// `colours` may be drawn.
+func newGame(gameText string, lineNum int, colours []string) (*Game, error) {
+	gameId, draws, err := parseGame(gameText, lineNum, colours)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the maximum number drawn of each color in this game
+	drawn := lo.SliceToMap(colours, func(colour string) (string, int) { return colour, 0 })
+	funk.ForEach(draws, func(draw map[string]int) {
+		for colour, count := range draw {
+			drawn[colour] = max(drawn[colour], count)
+		}
+	})
+
+	return &Game{
+		id:    gameId,
+		draws: lo.Map(draws, func(draw map[string]int, _ int) Bag { return draw }),
+		drawn: drawn,
+	}, nil
+}
+
+func (g *Game) ID() int {
+	return g.id
+}
+
+// Whether every draw of the game could have been made from `bag`.
+func (g *Game) PossibleWith(bag Bag) bool {
+	for colour, count := range g.drawn {
+		if count > bag[colour] {
+			return false
+		}
+	}
+	return true
+}
+
+// The power of the smallest bag of the puzzle's colours the game is
+// possible with: the product of the most red, green and blue cubes drawn
+// at once. Other colours the game was played with are left out, so the
+// power doesn't depend on which colours were allowed.
+func (g *Game) Power() int {
+	power := 1
+	for _, colour := range DefaultBag().Colours() {
+		power *= g.drawn[colour]
+	}
+	return power
+}
+
+// Reads the game records from `r`, one per line, and returns the
+// resulting Games, in the same order. Only cubes of `colours` may be drawn, or of the colours
+// of DefaultBag if `colours` is empty.
+func Play(r io.Reader, colours []string) ([]*Game, error) {
+	if len(colours) == 0 {
+		colours = DefaultBag().Colours()
+	}
+
+	scanner := bufio.NewScanner(r)
+
+	var lines []string
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// Games are parsed concurrently, in the order of the lines, and the
+	// first malformed line is reported
+	games, err := pool.Map(context.Background(), 0, lo.Range(len(lines)), func(i int) (*Game, error) {
+		return newGame(lines[i], i+1, colours)
+	})
+	if perr, ok := err.(*pool.Error); ok {
+		return nil, perr.Err
+	}
+	return games, err
+}
+
+// Returns the IDs of the games that are possible with `bag`, in
+// ascending order.
+func PossibleIds(games []*Game, bag Bag) []int {
+	return PossibleIdsByBag(games, map[string]Bag{"": bag})[""]
+}
+
+// Returns, for each of the named `bags`, the IDs of the games that are
+// possible with it, in ascending order. Every bag is checked in the same
+// pass over the games.
+func PossibleIdsByBag(games []*Game, bags map[string]Bag) map[string][]int {
+	ids := lo.MapValues(bags, func(Bag, string) []int { return []int{} })
+	for _, game := range games {
+		for name, bag := range bags {
+			if game.PossibleWith(bag) {
+				ids[name] = append(ids[name], game.id)
+			}
+		}
+	}
+	for _, curr := range ids {
+		slices.Sort(curr)
+	}
+	return ids
+}
+
+func SumOfPossibleIds(games []*Game, bag Bag) int {
+	return funk.SumInt(PossibleIds(games, bag))
+}
+
+func SumOfPowers(games []*Game) int {
+	return funk.Reduce(
+		games,
+		func(acc int, g *Game) int {
+			return acc + g.Power()
+		},
+		0,
+	).(int)
+}
@@ -0,0 +1,200 @@
+package cubegame
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// The grammar of a game record:
+//
+//	game  = "Game" number ":" draw { ";" draw }
+//	draw  = cubes { "," cubes }
+//	cubes = number colour
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenWord
+	tokenNumber
+	tokenColon
+	tokenSemicolon
+	tokenComma
+	tokenInvalid
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	// 1-based byte offset of the token in its line
+	column int
+}
+
+// SyntaxError is returned when a game record does not follow the grammar.
+// Line and Column are 1-based, and Token is the offending token ("" at the
+// end of the line).
+type SyntaxError struct {
+	Line   int
+	Column int
+	Token  string
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	at := "end of line"
+	if e.Token != "" {
+		at = strconv.Quote(e.Token)
+	}
+	return fmt.Sprintf("line %d, column %d: %s, at %s", e.Line, e.Column, e.Msg, at)
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// Splits `line` into tokens, skipping whitespace. The last token is
+// always tokenEnd.
+func tokenize(line string) []token {
+	var tokens []token
+	for i := 0; i < len(line); {
+		c := line[i]
+		start := i
+		kind := tokenInvalid
+		switch {
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+			continue
+		case isLetter(c):
+			for i < len(line) && isLetter(line[i]) {
+				i++
+			}
+			kind = tokenWord
+		case isDigit(c):
+			for i < len(line) && isDigit(line[i]) {
+				i++
+			}
+			kind = tokenNumber
+		case c == ':':
+			i, kind = i+1, tokenColon
+		case c == ';':
+			i, kind = i+1, tokenSemicolon
+		case c == ',':
+			i, kind = i+1, tokenComma
+		default:
+			i++
+		}
+		tokens = append(tokens, token{kind: kind, text: line[start:i], column: start + 1})
+	}
+	return append(tokens, token{kind: tokenEnd, column: len(line) + 1})
+}
+
+type parser struct {
+	line    int
+	tokens  []token
+	pos     int
+	colours []string
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEnd {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) errorAt(tok token, format string, args ...any) error {
+	return &SyntaxError{Line: p.line, Column: tok.column, Token: tok.text, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Consumes the next token, which must be of `kind`.
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	tok := p.next()
+	if tok.kind != kind {
+		return tok, p.errorAt(tok, "expected %s", what)
+	}
+	return tok, nil
+}
+
+func (p *parser) number(what string) (int, error) {
+	tok, err := p.expect(tokenNumber, what)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(tok.text)
+	if err != nil {
+		return 0, p.errorAt(tok, "%s out of range", what)
+	}
+	return n, nil
+}
+
+// Parses a draw, returning the number of cubes drawn of each colour.
+func (p *parser) draw() (map[string]int, error) {
+	counts := map[string]int{}
+	for {
+		count, err := p.number("cube count")
+		if err != nil {
+			return nil, err
+		}
+		colour, err := p.expect(tokenWord, "colour")
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(p.colours, colour.text) {
+			return nil, p.errorAt(colour, "unknown colour")
+		}
+		if _, ok := counts[colour.text]; ok {
+			return nil, p.errorAt(colour, "duplicate colour in draw")
+		}
+		counts[colour.text] = count
+
+		if p.peek().kind != tokenComma {
+			return counts, nil
+		}
+		p.next()
+	}
+}
+
+// Parses the game record `line`, found at line number `lineNum`, returning
+// its ID and draws. Only cubes of `colours` may be drawn.
+func parseGame(line string, lineNum int, colours []string) (int, []map[string]int, error) {
+	p := &parser{line: lineNum, tokens: tokenize(line), colours: colours}
+
+	if tok := p.next(); tok.kind != tokenWord || tok.text != "Game" {
+		return 0, nil, p.errorAt(tok, "expected \"Game\"")
+	}
+	id, err := p.number("game ID")
+	if err != nil {
+		return 0, nil, err
+	}
+	if _, err := p.expect(tokenColon, "':'"); err != nil {
+		return 0, nil, err
+	}
+
+	var draws []map[string]int
+	for {
+		draw, err := p.draw()
+		if err != nil {
+			return 0, nil, err
+		}
+		draws = append(draws, draw)
+
+		tok := p.next()
+		switch tok.kind {
+		case tokenEnd:
+			return id, draws, nil
+		case tokenSemicolon:
+		default:
+			return 0, nil, p.errorAt(tok, "expected ',', ';' or end of line")
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package cubegame
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseGame(t *testing.T) {
+	id, draws, err := parseGame("Game 12: 3 blue, 4 red; 1 red, 2 green, 6 blue; 2 green", 1, DefaultBag().Colours())
+	if err != nil {
+		t.Fatalf("[TestParseGame] unexpected error '%s'", err.Error())
+	}
+	if id != 12 {
+		t.Fatalf("[TestParseGame] expected ID 12, actual %d", id)
+	}
+	expected := []map[string]int{
+		{"blue": 3, "red": 4},
+		{"red": 1, "green": 2, "blue": 6},
+		{"green": 2},
+	}
+	if !reflect.DeepEqual(draws, expected) {
+		t.Fatalf("[TestParseGame] expected draws %v, actual %v", expected, draws)
+	}
+
+	// whitespace and CRLF line endings are tolerated
+	if _, _, err := parseGame("Game 1 :3 blue,4 red ;  1 red\r", 1, DefaultBag().Colours()); err != nil {
+		t.Fatalf("[TestParseGame] unexpected error '%s'", err.Error())
+	}
+}
+
+func TestParseGame_errors(t *testing.T) {
+	cases := []struct {
+		line   string
+		column int
+		token  string
+		msg    string
+	}{
+		{"", 1, "", "expected \"Game\""},
+		{"Gaem 1: 3 blue", 1, "Gaem", "expected \"Game\""},
+		{"Game: 3 blue", 5, ":", "expected game ID"},
+		{"Game 1 3 blue", 8, "3", "expected ':'"},
+		{"Game 1:", 8, "", "expected cube count"},
+		{"Game 1: blue", 9, "blue", "expected cube count"},
+		{"Game 1: 3", 10, "", "expected colour"},
+		{"Game 1: 3 purple", 11, "purple", "unknown colour"},
+		{"Game 1: 3 blue, 4 red, 5 blue", 26, "blue", "duplicate colour in draw"},
+		{"Game 1: 3 blue;", 16, "", "expected cube count"},
+		{"Game 1: 3 blue 4 red", 16, "4", "expected ',', ';' or end of line"},
+		{"Game 1: 3 blue; 4 red, ", 24, "", "expected cube count"},
+		{"Game 1: 3 blue! 4 red", 15, "!", "expected ',', ';' or end of line"},
+		{"Game 99999999999999999999: 3 blue", 6, "99999999999999999999", "game ID out of range"},
+	}
+
+	for _, c := range cases {
+		_, _, err := parseGame(c.line, 7, DefaultBag().Colours())
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Fatalf("[TestParseGame_errors] for %q expected a *SyntaxError, actual %v", c.line, err)
+		}
+		expected := SyntaxError{Line: 7, Column: c.column, Token: c.token, Msg: c.msg}
+		if *syntaxErr != expected {
+			t.Fatalf("[TestParseGame_errors] for %q expected %+v, actual %+v", c.line, expected, *syntaxErr)
+		}
+	}
+}
+
+func TestPlay_error(t *testing.T) {
+	doc := strings.Join([]string{
+		"Game 1: 3 blue",
+		"Game 2: 3 blue",
+		"Game 3: 3 bleu",
+		"Game 4: 3 blue, 3 blue",
+	}, "\n")
+
+	_, err := Play(strings.NewReader(doc), nil)
+	expected := `line 3, column 11: unknown colour, at "bleu"`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("[TestPlay_error] expected error %q, actual %v", expected, err)
+	}
+}
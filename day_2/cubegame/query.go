@@ -0,0 +1,129 @@
+package cubegame
+
+import (
+	"errors"
+	"fmt"
+	"github.com/samber/lo"
+	"slices"
+)
+
+// ErrNoBag is returned when no bag makes exactly the requested games
+// possible.
+var ErrNoBag = errors.New("no bag makes exactly these games possible")
+
+// MinimalBag returns the smallest bag the game is possible with: the most
+// cubes of each colour drawn at once. Colours that may be drawn but never
+// were are present with a count of 0.
+func (g *Game) MinimalBag() Bag {
+	return lo.Assign(g.drawn)
+}
+
+// MinimalBag returns the smallest bag every one of `games` is possible with.
+func MinimalBag(games ...*Game) Bag {
+	bag := Bag{}
+	for _, game := range games {
+		for colour, count := range game.drawn {
+			bag[colour] = max(bag[colour], count)
+		}
+	}
+	return bag
+}
+
+// A Shortfall is a colour of a draw that needed more cubes than the bag
+// holds.
+type Shortfall struct {
+	// 1-based index of the draw in the game
+	Draw   int
+	Colour string
+	Drawn  int
+	InBag  int
+}
+
+// By how many cubes the bag fell short.
+func (s Shortfall) By() int {
+	return s.Drawn - s.InBag
+}
+
+func (s Shortfall) String() string {
+	return fmt.Sprintf("draw %d: %d %s drawn, %d more than the %d in the bag", s.Draw, s.Drawn, s.Colour, s.By(), s.InBag)
+}
+
+// Shortfalls explains why the game is impossible with `bag`, returning
+// every colour of every draw that needed more cubes than `bag` holds, in
+// draw order and then by colour. It is empty if the game is possible.
+func (g *Game) Shortfalls(bag Bag) []Shortfall {
+	var shortfalls []Shortfall
+	for i, draw := range g.draws {
+		for _, colour := range draw.Colours() {
+			if draw[colour] > bag[colour] {
+				shortfalls = append(shortfalls, Shortfall{
+					Draw:   i + 1,
+					Colour: colour,
+					Drawn:  draw[colour],
+					InBag:  bag[colour],
+				})
+			}
+		}
+	}
+	return shortfalls
+}
+
+// BagRange describes a set of bags: those holding at least Min, but not
+// at least any of Exclude. A bag holds at least another if it has as many
+// cubes of each colour.
+type BagRange struct {
+	Min     Bag
+	Exclude []Bag
+}
+
+// Whether `bag` holds at least as many cubes of every colour as `other`.
+func holdsAtLeast(bag, other Bag) bool {
+	for colour, count := range other {
+		if bag[colour] < count {
+			return false
+		}
+	}
+	return true
+}
+
+// Contains returns whether `bag` is in the range.
+func (r *BagRange) Contains(bag Bag) bool {
+	if !holdsAtLeast(bag, r.Min) {
+		return false
+	}
+	for _, exclude := range r.Exclude {
+		if holdsAtLeast(bag, exclude) {
+			return false
+		}
+	}
+	return true
+}
+
+// BagsForExactly returns the range of bags with which, of `games`, exactly
+// the games with IDs `ids` are possible.
+//
+// A game is possible with every bag holding at least its minimal bag, so
+// the bags must hold at least the minimal bag of the `ids` games, and
+// must not hold at least the minimal bag of any other game. Adding cubes
+// never makes a game impossible, so if the smallest such bag makes another
+// game possible, so does every bag, and ErrNoBag is returned.
+func BagsForExactly(games []*Game, ids []int) (*BagRange, error) {
+	for _, id := range ids {
+		if !slices.ContainsFunc(games, func(g *Game) bool { return g.id == id }) {
+			return nil, fmt.Errorf("no game with ID %d", id)
+		}
+	}
+
+	included, excluded := lo.FilterReject(games, func(g *Game, _ int) bool {
+		return slices.Contains(ids, g.id)
+	})
+	r := &BagRange{Min: MinimalBag(included...)}
+	for _, game := range excluded {
+		minimal := game.MinimalBag()
+		if holdsAtLeast(r.Min, minimal) {
+			return nil, fmt.Errorf("%w: game %d is possible with every bag the others are", ErrNoBag, game.id)
+		}
+		r.Exclude = append(r.Exclude, minimal)
+	}
+	return r, nil
+}
@@ -0,0 +1,109 @@
+package cubegame
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// The games of testdata/example.txt, in order.
+func exampleGames(t *testing.T, colours []string) []*Game {
+	file, err := os.Open("testdata/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	games, err := Play(file, colours)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return games
+}
+
+func TestMinimalBag(t *testing.T) {
+	games := exampleGames(t, nil)
+
+	expected := []Bag{
+		{"red": 4, "green": 2, "blue": 6},
+		{"red": 1, "green": 3, "blue": 4},
+		{"red": 20, "green": 13, "blue": 6},
+		{"red": 14, "green": 3, "blue": 15},
+		{"red": 6, "green": 3, "blue": 2},
+	}
+	for i, game := range games {
+		if actual := game.MinimalBag(); !reflect.DeepEqual(actual, expected[i]) {
+			t.Fatalf("[TestMinimalBag] for game %d expected %v, actual %v", game.ID(), expected[i], actual)
+		}
+	}
+
+	all := Bag{"red": 20, "green": 13, "blue": 15}
+	if actual := MinimalBag(games...); !reflect.DeepEqual(actual, all) {
+		t.Fatalf("[TestMinimalBag] expected %v, actual %v", all, actual)
+	}
+	if actual := MinimalBag(); len(actual) != 0 {
+		t.Fatalf("[TestMinimalBag] expected an empty bag, actual %v", actual)
+	}
+}
+
+func TestGame_Shortfalls(t *testing.T) {
+	games := exampleGames(t, nil)
+
+	if actual := games[0].Shortfalls(DefaultBag()); len(actual) != 0 {
+		t.Fatalf("[TestGame_Shortfalls] expected game 1 to be possible, actual %v", actual)
+	}
+
+	expected := []Shortfall{
+		{Draw: 3, Colour: "blue", Drawn: 15, InBag: 14},
+		{Draw: 3, Colour: "red", Drawn: 14, InBag: 12},
+	}
+	actual := games[3].Shortfalls(DefaultBag())
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("[TestGame_Shortfalls] expected %v, actual %v", expected, actual)
+	}
+	if actual[1].By() != 2 || actual[1].String() != "draw 3: 14 red drawn, 2 more than the 12 in the bag" {
+		t.Fatalf("[TestGame_Shortfalls] unexpected explanation %q", actual[1].String())
+	}
+}
+
+func TestBagsForExactly(t *testing.T) {
+	games := exampleGames(t, nil)
+
+	r, err := BagsForExactly(games, []int{1, 2, 5})
+	if err != nil {
+		t.Fatalf("[TestBagsForExactly] unexpected error '%s'", err.Error())
+	}
+	if !reflect.DeepEqual(r.Min, Bag{"red": 6, "green": 3, "blue": 6}) {
+		t.Fatalf("[TestBagsForExactly] unexpected minimum %v", r.Min)
+	}
+	for bag, contains := range map[string]bool{
+		"red=6,green=3,blue=6":    true,
+		"red=12,green=13,blue=14": true,
+		"red=5,green=3,blue=6":    false,
+		"red=19,green=13,blue=14": true,
+		"red=20,green=13,blue=14": false,
+		"red=14,green=3,blue=15":  false,
+	} {
+		b, _ := ParseBag(bag)
+		if r.Contains(b) != contains {
+			t.Fatalf("[TestBagsForExactly] expected Contains(%s) to be %v", bag, contains)
+		}
+		// every bag in the range makes exactly the games possible
+		if contains && !reflect.DeepEqual(PossibleIds(games, b), []int{1, 2, 5}) {
+			t.Fatalf("[TestBagsForExactly] expected bag %s to make games [1 2 5] possible", bag)
+		}
+	}
+
+	if _, err := BagsForExactly(games, nil); err != nil {
+		t.Fatalf("[TestBagsForExactly] unexpected error '%s'", err.Error())
+	}
+	for _, ids := range [][]int{{1, 3}, {4}, {3, 4}} {
+		if _, err := BagsForExactly(games, ids); !errors.Is(err, ErrNoBag) {
+			t.Fatalf("[TestBagsForExactly] for %v expected %v, actual %v", ids, ErrNoBag, err)
+		}
+	}
+	if _, err := BagsForExactly(games, []int{6}); err == nil || errors.Is(err, ErrNoBag) {
+		t.Fatalf("[TestBagsForExactly] expected an unknown ID error, actual %v", err)
+	}
+}
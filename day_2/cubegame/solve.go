@@ -0,0 +1,66 @@
+package cubegame
+
+import (
+	"github.com/samber/lo"
+	"github.com/ubermensch/advent_of_code_2023/solver"
+	"io"
+)
+
+// Solver solves the day 2 puzzle.
+var Solver = &solver.Day{
+	Number: 2,
+	Part1:  part1,
+	Part2:  part2,
+}
+
+// Result holds the answers about a set of game records for a bag.
+type Result struct {
+	Games            int
+	PossibleIds      []int
+	SumOfPossibleIds int
+	SumOfPowers      int
+}
+
+// Solve reads the game records from `r`, one per line, and answers both
+// parts of the puzzle for `bag`. Cubes of the colours of DefaultBag, and
+// of the colours of `bag`, may be drawn.
+func Solve(r io.Reader, bag Bag) (Result, error) {
+	colours := lo.Uniq(append(DefaultBag().Colours(), bag.Colours()...))
+	games, err := Play(r, colours)
+	if err != nil {
+		return Result{}, err
+	}
+
+	ids := PossibleIds(games, bag)
+	return Result{
+		Games:            len(games),
+		PossibleIds:      ids,
+		SumOfPossibleIds: lo.Sum(ids),
+		SumOfPowers:      SumOfPowers(games),
+	}, nil
+}
+
+func part1(r io.Reader) (solver.Result, error) {
+	res, err := Solve(r, DefaultBag())
+	if err != nil {
+		return solver.Result{}, err
+	}
+	return solver.Result{
+		Answer: res.SumOfPossibleIds,
+		Stats: solver.Stats{
+			"games":        res.Games,
+			"possible_ids": res.PossibleIds,
+		},
+	}, nil
+}
+
+func part2(r io.Reader) (solver.Result, error) {
+	res, err := Solve(r, DefaultBag())
+	if err != nil {
+		return solver.Result{}, err
+	}
+	return solver.Result{
+		Answer: res.SumOfPowers,
+		Stats:  solver.Stats{"games": res.Games},
+	}, nil
+}
@@ -0,0 +1,60 @@
+package cubegame
+
+import (
+	"bytes"
+	"github.com/ubermensch/advent_of_code_2023/solver"
+	"github.com/ubermensch/advent_of_code_2023/solver/solvertest"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestSolver_examples(t *testing.T) {
+	solvertest.Run(t, Solver, []solvertest.Example{
+		{File: "example.txt", Part: solver.One, Want: 8},
+		{File: "example.txt", Part: solver.Two, Want: 2286},
+	})
+}
+
+func TestSolve(t *testing.T) {
+	example, err := os.ReadFile("testdata/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Result{Games: 5, PossibleIds: []int{1, 2, 5}, SumOfPossibleIds: 8, SumOfPowers: 2286}
+
+	// no state is shared between calls, so concurrent calls agree
+	var wg sync.WaitGroup
+	results := make([]Result, 8)
+	errs := make([]error, 8)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = Solve(bytes.NewReader(example), DefaultBag())
+		}()
+	}
+	wg.Wait()
+
+	for i, actual := range results {
+		if errs[i] != nil {
+			t.Fatalf("[TestSolve] unexpected error '%s'", errs[i].Error())
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("[TestSolve] expected %+v, actual %+v", expected, actual)
+		}
+	}
+
+	// a bag with another colour
+	actual, err := Solve(bytes.NewReader(example), Bag{"red": 20, "green": 13, "blue": 15, "purple": 1})
+	if err != nil {
+		t.Fatalf("[TestSolve] unexpected error '%s'", err.Error())
+	}
+	if actual.SumOfPossibleIds != 15 {
+		t.Fatalf("[TestSolve] expected every game to be possible, actual %v", actual.PossibleIds)
+	}
+	if actual.SumOfPowers != 2286 {
+		t.Fatalf("[TestSolve] expected the sum of powers to be unaffected by the bag, actual %d", actual.SumOfPowers)
+	}
+}
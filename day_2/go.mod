@@ -1,5 +0,0 @@
-module day_2
-
-go 1.21.7
-
-require github.com/thoas/go-funk v0.9.3 // indirect
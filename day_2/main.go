@@ -1,197 +1,112 @@
 package main
 
 import (
-	"bufio"
-	"errors"
+	"flag"
 	"fmt"
-	"github.com/thoas/go-funk"
+	"github.com/samber/lo"
+	"github.com/ubermensch/advent_of_code_2023/day_2/cubegame"
+	"github.com/ubermensch/advent_of_code_2023/input"
+	"github.com/ubermensch/advent_of_code_2023/solver"
+	"log"
 	"os"
-	"path"
-	"strconv"
+	"slices"
 	"strings"
 )
 
-const inputFile = "/Users/frankhmeidan/golang/advent_of_code/day_2/input.txt"
+// The bags given with -bag, in the order given.
+type bagList []cubegame.Bag
 
-const (
-	maxGreen = 13
-	maxRed   = 12
-	maxBlue  = 14
-)
-
-var gameChannel = make(chan *Game)
-var games []*Game
-
-type Game struct {
-	id         int
-	blueDrawn  int
-	greenDrawn int
-	redDrawn   int
-	power      int
-	isPossible bool
+func (l *bagList) String() string {
+	return strings.Join(lo.Map(*l, func(bag cubegame.Bag, _ int) string { return bag.String() }), " ")
 }
 
-type Draw struct {
-	blue  int
-	green int
-	red   int
+func (l *bagList) Set(s string) error {
+	bag, err := cubegame.ParseBag(s)
+	if err != nil {
+		return err
+	}
+	*l = append(*l, bag)
+	return nil
 }
 
-func (draw *Draw) setColors(drawStr string) error {
-	var finalErr error = nil
+var (
+	inputPath = flag.String("input", "", "path to the puzzle input, or - to read stdin")
+	part      solver.Part
+	bags      bagList
+	bagsPath  = flag.String("bags", "", "path to a TOML file of named bags to check the games against")
+	explain   = flag.Bool("explain", false, "with -bag or -bags, explain why each impossible game is impossible")
+)
 
-	pieces := strings.Split(drawStr, ",")
-	funk.ForEach(pieces, func(s string) {
-		pieces := strings.Split(strings.Trim(s, " "), " ")
-		color := pieces[1]
-		count, err := strconv.Atoi(pieces[0])
+// Loads the bags given with -bag and -bags, by name. Bags given with -bag
+// are named by their contents.
+func loadBags() (map[string]cubegame.Bag, error) {
+	named := map[string]cubegame.Bag{}
+	if *bagsPath != "" {
+		file, err := os.Open(*bagsPath)
 		if err != nil {
-			finalErr = errors.New("could not parse draw for string: " + drawStr)
-			return
-		}
-		switch color {
-		case "green":
-			draw.green = count
-		case "blue":
-			draw.blue = count
-		case "red":
-			draw.red = count
-		default:
-			finalErr = errors.New("could not parse draw for string: " + drawStr)
+			return nil, err
 		}
-	})
+		defer file.Close()
 
-	return finalErr
-}
-
-func parseDraw(drawStr string) (*Draw, error) {
-	draw := &Draw{
-		green: 0,
-		red:   0,
-		blue:  0,
+		if named, err = cubegame.LoadBags(file); err != nil {
+			return nil, err
+		}
 	}
-
-	err := draw.setColors(drawStr)
-	return draw, err
-}
-
-func setIsPossible(game *Game) {
-	if game.blueDrawn <= maxBlue &&
-		game.redDrawn <= maxRed &&
-		game.greenDrawn <= maxGreen {
-		game.isPossible = true
-	} else {
-		game.isPossible = false
+	for _, bag := range bags {
+		named[bag.String()] = bag
 	}
+	return named, nil
 }
 
-// Turns the game text line from the input file into a Game struct and returns
-// the pointer to it
-func newGame(gameText string) (*Game, error) {
-	idAndGame := strings.Split(gameText, ":")
-	idStr, gameStr := idAndGame[0], idAndGame[1]
+func main() {
+	flag.Var(&part, "part", "part to solve: 1, 2 or both")
+	flag.Var(&bags, "bag", "bag to check the games against, as colour=count pairs such as red=12,green=13,blue=14 (repeatable)")
+	flag.Parse()
 
-	gameId, err := strconv.Atoi(strings.Split(idStr, " ")[1])
+	named, err := loadBags()
 	if err != nil {
-		return nil, errors.New("could not determine game ID")
+		log.Fatal(err)
 	}
 
-	drawStrings := strings.Split(gameStr, ";")
-	var draws []*Draw
-	for _, currDraw := range drawStrings {
-		draw, err := parseDraw(currDraw)
-		if err != nil {
-			return nil, errors.New("could not parse game string: " + currDraw)
-		}
-		draws = append(draws, draw)
+	file, err := input.Open(2, *inputPath)
+	if err != nil {
+		log.Fatal(err)
 	}
+	defer file.Close()
 
-	// Find the maximum number drawn of each color in this game
-	blueMax, greenMax, redMax := 0, 0, 0
-	funk.ForEach(draws, func(draw *Draw) {
-		if draw.blue > blueMax {
-			blueMax = draw.blue
-		}
-		if draw.green > greenMax {
-			greenMax = draw.green
+	if len(named) == 0 {
+		results, err := cubegame.Solver.Solve(file, part)
+		if err != nil {
+			log.Fatal(err)
 		}
-		if draw.red > redMax {
-			redMax = draw.red
+		for _, result := range results {
+			fmt.Println(result)
 		}
-	})
-
-	game := &Game{
-		id:         gameId,
-		blueDrawn:  blueMax,
-		greenDrawn: greenMax,
-		redDrawn:   redMax,
-		power:      blueMax * greenMax * redMax,
-		isPossible: false,
+		return
 	}
 
-	setIsPossible(game)
-	return game, nil
-}
-
-func main() {
-	filePath := path.Join(inputFile)
-	file, err := os.Open(filePath)
-	if err != nil {
-		panic("could not open file")
+	// Cubes of any colour in the puzzle or in one of the bags may be drawn
+	colours := cubegame.DefaultBag().Colours()
+	for _, bag := range named {
+		colours = append(colours, bag.Colours()...)
 	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
+	games, err := cubegame.Play(file, lo.Uniq(colours))
 	if err != nil {
-		panic("could not scan file")
-	}
-
-	var lines []string
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		lines = append(lines, line)
+		log.Fatal(err)
 	}
 
-	for _, line := range lines {
-		go func(l string) {
-			currGame, err := newGame(l)
-			if err != nil {
-				panic("could not create game")
+	ids := cubegame.PossibleIdsByBag(games, named)
+	names := lo.Keys(named)
+	slices.Sort(names)
+	for _, name := range names {
+		fmt.Printf("%s: %d possible games, sum of IDs %d\n", name, len(ids[name]), lo.Sum(ids[name]))
+		if !*explain {
+			continue
+		}
+		for _, game := range games {
+			for _, shortfall := range game.Shortfalls(named[name]) {
+				fmt.Printf("  game %d %s\n", game.ID(), shortfall)
 			}
-			gameChannel <- currGame
-		}(line)
-	}
-	for i := 0; i < len(lines); i++ {
-		games = append(games, <-gameChannel)
+		}
 	}
-
-	possible := funk.Filter(games, func(game *Game) bool {
-		return game.isPossible
-	})
-
-	sumOfPossibleIds := funk.Reduce(
-		possible,
-		func(acc int, g *Game) int {
-			return acc + g.id
-		},
-		0,
-	)
-
-	sumOfPowers := funk.Reduce(
-		games,
-		func(acc int, g *Game) int {
-			return acc + g.power
-		},
-		0,
-	)
-
-	fmt.Println(
-		fmt.Sprintf(
-			"Possible game IDs: %v\nSum of IDs: %d\nSum of Powers: %d\n",
-			funk.Map(possible, func(g *Game) int { return g.id }),
-			sumOfPossibleIds,
-			sumOfPowers,
-		),
-	)
 }
@@ -1,9 +0,0 @@
-module day_3
-
-go 1.21.7
-
-require (
-	github.com/samber/lo v1.39.0 // indirect
-	github.com/thoas/go-funk v0.9.3 // indirect
-	golang.org/x/exp v0.0.0-20220303212507-bbda1eaf7a17 // indirect
-)
@@ -1,25 +1,93 @@
 package main
 
 import (
-	"day_3/schematic"
+	"cmp"
+	"flag"
 	"fmt"
+	"github.com/samber/lo"
+	"github.com/ubermensch/advent_of_code_2023/day_3/schematic"
+	"github.com/ubermensch/advent_of_code_2023/input"
+	"github.com/ubermensch/advent_of_code_2023/solver"
 	"log"
+	"os"
+	"strings"
 )
 
+var (
+	inputPath = flag.String("input", "", "path to the puzzle input, or - to read stdin")
+	part      solver.Part
+	render    = flag.String("render", "", "instead of solving, render the annotated schematic as ansi or html")
+	symbols   = flag.String("symbols", "", "the runes that count as symbols, such as \"*#\" (defaults to anything but '.' and digits)")
+	explain   = flag.Bool("explain", false, "instead of solving, list each part number with the symbols it is adjacent to")
+)
+
+// The symbol class selected with -symbols.
+func symbolClass() schematic.SymbolClass {
+	if *symbols == "" {
+		return schematic.AnySymbol
+	}
+	return schematic.RuneSet([]rune(*symbols)...)
+}
+
 func main() {
-	s, err := schematic.NewSchematic()
+	flag.Var(&part, "part", "part to solve: 1, 2 or both")
+	flag.Parse()
+
+	file, err := input.Open(3, *inputPath)
 	if err != nil {
 		log.Fatal(err)
 	}
-	valid, err := s.ValidPartNumbers()
+	defer file.Close()
+
+	if *render != "" || *explain || *symbols != "" {
+		s, err := schematic.ParseWith(file, symbolClass())
+		if err != nil {
+			log.Fatal(err)
+		}
+		switch *render {
+		case "":
+			err = explainPartNumbers(s)
+		case "ansi":
+			err = s.RenderANSI(os.Stdout)
+		case "html":
+			err = s.RenderHTML(os.Stdout)
+		default:
+			err = fmt.Errorf("invalid -render %q, expected ansi or html", *render)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	results, err := schematic.Solver.Solve(file, part)
 	if err != nil {
 		log.Fatal(err)
 	}
+	for _, result := range results {
+		fmt.Println(result)
+	}
+}
 
+// Lists each part number with the symbols it is adjacent to, then the sum
+// of the valid ones.
+func explainPartNumbers(s *schematic.Schematic) error {
 	sum := 0
-	for _, curr := range valid {
-		sum += curr.Number
+	for _, pn := range s.PartNumbers() {
+		adjacent, err := s.SymbolsAdjacentTo(pn)
+		if err != nil {
+			return err
+		}
+		if *explain {
+			symbols := lo.Map(adjacent, func(sym schematic.Symbol, _ int) string {
+				return fmt.Sprintf("%c at %d,%d", sym.Value, sym.X, sym.Y)
+			})
+			fmt.Printf("%s: %s\n", pn.ToString(), cmp.Or(strings.Join(symbols, ", "), "no symbols, excluded"))
+		}
+		if len(adjacent) > 0 {
+			sum += pn.Number
+		}
 	}
-
-	fmt.Println(fmt.Sprintf("Sum of valid part numbers is: %d", sum))
+	fmt.Printf("sum of part numbers adjacent to a symbol: %d\n", sum)
+	return nil
 }
@@ -0,0 +1,52 @@
+package schematic
+
+import (
+	"cmp"
+	"github.com/ubermensch/advent_of_code_2023/grid"
+	"slices"
+)
+
+// A Gear is a '*' symbol adjacent to exactly two part numbers.
+type Gear struct {
+	X int // x-position in the Schematic line (0-indexed)
+	Y int // y-position in the Schematic, the line number (0-indexed)
+
+	PartNumbers [2]*PartNumber
+
+	// The product of the two part numbers
+	Ratio int
+}
+
+// Gears returns the gears of the schematic, in reading order.
+func (s *Schematic) Gears() ([]*Gear, error) {
+	// The part numbers adjacent to each '*'
+	adjacent := map[grid.Pos][]*PartNumber{}
+	for _, pn := range s.partNumbers {
+		points, err := s.adjacentTo(pn)
+		if err != nil {
+			return nil, err
+		}
+		for _, point := range points {
+			if value, _ := s.grid.Get(point); value == '*' {
+				adjacent[point] = append(adjacent[point], pn)
+			}
+		}
+	}
+
+	var gears []*Gear
+	for point, pns := range adjacent {
+		if len(pns) != 2 {
+			continue
+		}
+		gears = append(gears, &Gear{
+			X:           point.X,
+			Y:           point.Y,
+			PartNumbers: [2]*PartNumber{pns[0], pns[1]},
+			Ratio:       pns[0].Number * pns[1].Number,
+		})
+	}
+	slices.SortFunc(gears, func(a, b *Gear) int {
+		return cmp.Or(cmp.Compare(a.Y, b.Y), cmp.Compare(a.X, b.X))
+	})
+	return gears, nil
+}
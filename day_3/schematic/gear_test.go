@@ -0,0 +1,42 @@
+package schematic
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSchematic_Gears(t *testing.T) {
+	file, err := os.Open("testdata/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	s, err := Parse(file)
+	if err != nil {
+		t.Fatalf("[TestSchematic_Gears] unexpected error '%s'", err.Error())
+	}
+	gears, err := s.Gears()
+	if err != nil {
+		t.Fatalf("[TestSchematic_Gears] unexpected error '%s'", err.Error())
+	}
+
+	// The '*' at (3, 4) is adjacent to a single part number, so is not a gear
+	expected := []Gear{
+		{X: 3, Y: 1, Ratio: 467 * 35},
+		{X: 5, Y: 8, Ratio: 755 * 598},
+	}
+	expectedNumbers := [][2]int{{467, 35}, {755, 598}}
+	if len(gears) != len(expected) {
+		t.Fatalf("[TestSchematic_Gears] expected %d gears, actual %d", len(expected), len(gears))
+	}
+	for i, gear := range gears {
+		if gear.X != expected[i].X || gear.Y != expected[i].Y || gear.Ratio != expected[i].Ratio {
+			t.Fatalf("[TestSchematic_Gears] expected gear %+v, actual %+v", expected[i], *gear)
+		}
+		numbers := [2]int{gear.PartNumbers[0].Number, gear.PartNumbers[1].Number}
+		if numbers != expectedNumbers[i] {
+			t.Fatalf("[TestSchematic_Gears] expected part numbers %v, actual %v", expectedNumbers[i], numbers)
+		}
+	}
+}
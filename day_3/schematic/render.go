@@ -0,0 +1,158 @@
+package schematic
+
+import (
+	"bufio"
+	"github.com/ubermensch/advent_of_code_2023/grid"
+	"html/template"
+	"io"
+)
+
+// How a point of the schematic is shown when rendered.
+type pointKind int
+
+const (
+	kindBlank pointKind = iota
+	kindValid
+	kindInvalid
+	kindSymbol
+	kindGear
+)
+
+// The CSS class of each kind of point in HTML renderings.
+var kindClasses = map[pointKind]string{
+	kindBlank:   "blank",
+	kindValid:   "valid",
+	kindInvalid: "invalid",
+	kindSymbol:  "symbol",
+	kindGear:    "gear",
+}
+
+// The ANSI escape sequence starting each kind of point in terminal
+// renderings.
+var kindEscapes = map[pointKind]string{
+	kindBlank:   "\x1b[2m",    // dim
+	kindValid:   "\x1b[32m",   // green
+	kindInvalid: "\x1b[31m",   // red
+	kindSymbol:  "\x1b[33m",   // yellow
+	kindGear:    "\x1b[1;35m", // bold magenta
+}
+
+const ansiReset = "\x1b[0m"
+
+// Returns the kind of every point of the schematic, by row.
+func (s *Schematic) pointKinds() ([][]pointKind, error) {
+	kinds := make([][]pointKind, s.grid.Height())
+	for y := range kinds {
+		kinds[y] = make([]pointKind, s.grid.Width(y))
+	}
+	s.grid.Each(func(p grid.Pos, value rune) {
+		if s.symbols(value) {
+			kinds[p.Y][p.X] = kindSymbol
+		}
+	})
+	for _, pn := range s.partNumbers {
+		kind := kindInvalid
+		if pn.isValid {
+			kind = kindValid
+		}
+		for _, point := range pn.points {
+			kinds[point.Y][point.X] = kind
+		}
+	}
+
+	gears, err := s.Gears()
+	if err != nil {
+		return nil, err
+	}
+	for _, gear := range gears {
+		kinds[gear.Y][gear.X] = kindGear
+	}
+	return kinds, nil
+}
+
+// A run of consecutive points of the same kind in a row.
+type span struct {
+	Class string
+	kind  pointKind
+	Text  string
+}
+
+// Returns the rows of the schematic, split into spans of points of the
+// same kind.
+func (s *Schematic) spans() ([][]span, error) {
+	kinds, err := s.pointKinds()
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]span, len(kinds))
+	for y, row := range kinds {
+		for x, kind := range row {
+			value := string(s.grid.Row(y)[x])
+			if n := len(rows[y]); n > 0 && rows[y][n-1].kind == kind {
+				rows[y][n-1].Text += value
+				continue
+			}
+			rows[y] = append(rows[y], span{Class: kindClasses[kind], kind: kind, Text: value})
+		}
+	}
+	return rows, nil
+}
+
+// RenderANSI writes the schematic to `w` coloured with ANSI escape
+// sequences: valid part numbers in green, invalid ones in red, symbols in
+// yellow and gears in bold magenta.
+func (s *Schematic) RenderANSI(w io.Writer) error {
+	rows, err := s.spans()
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, row := range rows {
+		for _, curr := range row {
+			bw.WriteString(kindEscapes[curr.kind] + curr.Text + ansiReset)
+		}
+		bw.WriteString("\n")
+	}
+	return bw.Flush()
+}
+
+var htmlTemplate = template.Must(template.New("schematic").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Schematic</title>
+<style>
+body { background: #1e1e1e; color: #d4d4d4; font-family: monospace; }
+pre { line-height: 1.2; }
+.blank { color: #555; }
+.valid { color: #6a9955; font-weight: bold; }
+.invalid { color: #f44747; }
+.symbol { color: #dcdcaa; }
+.gear { color: #1e1e1e; background: #c586c0; font-weight: bold; }
+</style>
+</head>
+<body>
+<p>
+<span class="valid">valid part number</span> &middot;
+<span class="invalid">invalid part number</span> &middot;
+<span class="symbol">symbol</span> &middot;
+<span class="gear">gear</span>
+</p>
+<pre>
+{{range .}}{{range .}}<span class="{{.Class}}">{{.Text}}</span>{{end}}
+{{end}}</pre>
+</body>
+</html>
+`))
+
+// RenderHTML writes the schematic to `w` as a self-contained HTML page,
+// coloured as RenderANSI does, with a legend.
+func (s *Schematic) RenderHTML(w io.Writer) error {
+	rows, err := s.spans()
+	if err != nil {
+		return err
+	}
+	return htmlTemplate.Execute(w, rows)
+}
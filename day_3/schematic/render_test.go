@@ -0,0 +1,58 @@
+package schematic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSchematic_RenderANSI(t *testing.T) {
+	s, err := ParseString("1.2\n*..\n3#&")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	if err := s.RenderANSI(&out); err != nil {
+		t.Fatalf("[TestSchematic_RenderANSI] unexpected error '%s'", err.Error())
+	}
+
+	// 1 and 3 are valid and share the gear, 2 is invalid
+	expected := strings.Join([]string{
+		"\x1b[32m1\x1b[0m\x1b[2m.\x1b[0m\x1b[31m2\x1b[0m",
+		"\x1b[1;35m*\x1b[0m\x1b[2m..\x1b[0m",
+		"\x1b[32m3\x1b[0m\x1b[33m#&\x1b[0m",
+		"",
+	}, "\n")
+	if out.String() != expected {
+		t.Fatalf("[TestSchematic_RenderANSI] expected %q, actual %q", expected, out.String())
+	}
+}
+
+func TestSchematic_RenderHTML(t *testing.T) {
+	s, err := ParseString("1.2\n*..\n3<&")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	if err := s.RenderHTML(&out); err != nil {
+		t.Fatalf("[TestSchematic_RenderHTML] unexpected error '%s'", err.Error())
+	}
+
+	html := out.String()
+	for _, expected := range []string{
+		`<span class="valid">1</span><span class="blank">.</span><span class="invalid">2</span>`,
+		`<span class="gear">*</span><span class="blank">..</span>`,
+		// symbols are escaped
+		`<span class="valid">3</span><span class="symbol">&lt;&amp;</span>`,
+		// and the page needs nothing else to be shown
+		"<style>",
+	} {
+		if !strings.Contains(html, expected) {
+			t.Fatalf("[TestSchematic_RenderHTML] expected the page to contain %q, actual %s", expected, html)
+		}
+	}
+	if strings.Contains(html, "<link") || strings.Contains(html, "<script") {
+		t.Fatalf("[TestSchematic_RenderHTML] expected a self-contained page")
+	}
+}
@@ -4,78 +4,62 @@ import (
 	"errors"
 	"fmt"
 	"github.com/samber/lo"
-	"regexp"
+	"github.com/ubermensch/advent_of_code_2023/grid"
+	"io"
 	"strconv"
 	"strings"
-	"unicode"
 )
 
 // PartNumber is the contiguous series of digits representing
 // an integer that we're looking for in the schema.
 type PartNumber struct {
 	Number  int
-	points  []*Point
+	points  []grid.Pos
 	isValid bool
 }
 type Schematic struct {
-	// The 2 dimensional grid of points represented by this schematic
-	points [][]*Point
+	// The 2 dimensional grid of points represented by this schematic.
+	// The value of a point could be either:
+	// * A digit (part of a number)
+	// * A symbol (non-`.`, not letter, not digit).
+	grid *grid.Grid[rune]
 
 	// The part numbers hidden in this schematic, i.e. the contiguous
 	// sequences of digits representing numbers.
 	partNumbers []*PartNumber
-}
 
-// A point in the schematic grid. The value for this point
-// could be either:
-// * A digit (part of a number)
-// * A symbol (non-`.`, not letter, not digit).
-type Point struct {
-	x     int  // x-position in the Schematic line (0-indexed)
-	y     int  // y-position in the Schematic, the line number (0-indexed)
-	value rune // the value of this point
+	// Which points count as symbols
+	symbols SymbolClass
 }
 
-func (p *Point) isDot() bool {
-	return p.value == '.'
+func isDot(value rune) bool {
+	return value == '.'
 }
-func (p *Point) isDigit() bool {
-
-	return unicode.IsDigit(p.value)
+func isDigit(value rune) bool {
+	return value >= '0' && value <= '9'
 }
 
 // For our purposes, a point is either:
 // * a digit (part of a part number)
 // * a dot (to be ignored), or
 // * a symbol (anything else)
-func (p *Point) isSymbol() bool {
-	return !p.isDot() && !p.isDigit()
+func isSymbol(value rune) bool {
+	return !isDot(value) && !isDigit(value)
 }
 
-func (pn *PartNumber) adjacentPoints() ([][]int, error) {
+// Returns the points of the schematic adjacent to the part number,
+// diagonals included, each point once.
+func (s *Schematic) adjacentTo(pn *PartNumber) ([]grid.Pos, error) {
 	if len(pn.points) == 0 {
 		return nil, errors.New("empty part number (points not set)")
 	}
-	var neighbours [][]int
-	// TODO - probably have bug here! Ensure diagonal symbol on single-digit number
-	// is supported
+
+	var adjacent []grid.Pos
 	for _, point := range pn.points {
-		neighbours = append(
-			neighbours,
-			[]int{point.x, point.y + 1},
-			[]int{point.x, point.y - 1},
-			[]int{point.x + 1, point.y + 1},
-			[]int{point.x + 1, point.y - 1},
-			[]int{point.x + 1, point.y},
-			[]int{point.x - 1, point.y + 1},
-			[]int{point.x - 1, point.y - 1},
-			[]int{point.x - 1, point.y},
-		)
+		adjacent = append(adjacent, s.grid.Neighbours8(point)...)
 	}
-
-	// We know there are some duplicates here,
-	// its fine for now...
-	return neighbours, nil
+	// A part number's own digits are not adjacent to it
+	return lo.Uniq(lo.Without(adjacent, pn.points...)), nil
 }
 
 // Print some debugging information for the PartNumber
@@ -83,16 +67,13 @@ func (pn *PartNumber) ToString() string {
 	return fmt.Sprintf(
 		"[%d] at [%d - %d, %d]",
 		pn.Number,
-		pn.points[0].x,
-		pn.points[len(pn.points)-1].x,
-		pn.points[0].y,
+		pn.points[0].X,
+		pn.points[len(pn.points)-1].X,
+		pn.points[0].Y,
 	)
 }
 
 func (s *Schematic) setPartNumberValidity() error {
-	if len(s.partNumbers) == 0 {
-		return errors.New("no part numbers set on schematic")
-	}
 	for _, pn := range s.partNumbers {
 		valid, err := s.isPartNumberValid(pn)
 		if err != nil {
@@ -103,56 +84,23 @@ func (s *Schematic) setPartNumberValidity() error {
 	return nil
 }
 
-// Given part number, is it valid in this schematic? i.e. is the first
+// Given part number, is it valid in this schematic? i.e. is any
 // point of this part number adjacent to a symbol point?
 func (s *Schematic) isPartNumberValid(pn *PartNumber) (bool, error) {
-	adjacent, err := pn.adjacentPoints()
+	symbols, err := s.symbolsAdjacentTo(pn, s.symbols)
 	if err != nil {
 		return false, err
 	}
-	hasAdjacentSymbol := lo.SomeBy(
-		adjacent,
-		func(point []int) bool {
-			col, row := point[0], point[1]
-
-			// Not valid if the adjacent point is outside of bounds
-			if row >= len(s.points) || row < 0 {
-				return false
-			}
-			if col >= len(s.points[row]) || col < 0 {
-				return false
-			}
-
-			// Otherwise, part number is valid if the
-			// adjacent point is a symbol
-			if s.points[row][col].isSymbol() {
-				return true
-			}
-
-			return false
-		},
-	)
-
-	if !hasAdjacentSymbol {
-		fmt.Println(
-			fmt.Sprintf(
-				"[Valid : %t] Part Number : %s",
-				hasAdjacentSymbol,
-				pn.ToString(),
-			),
-		)
-	}
 
 	// Part number is valid if we have at least 1 adjacent symbol
-	return hasAdjacentSymbol, nil
+	return len(symbols) > 0, nil
 }
 
 // Finds the part numbers hidden in the Schematic
 func (s *Schematic) setPartNumbers() error {
 	var parts []*PartNumber
-	for y := 0; y < len(s.points); y++ {
-		row := s.points[y]
-		rowPartNumbers, err := partNumbersFromRow(row)
+	for y := 0; y < s.grid.Height(); y++ {
+		rowPartNumbers, err := partNumbersFromRow(s.grid, y)
 		if err != nil {
 			return errors.New(
 				fmt.Sprintf("could not get part numbers from row %d", y),
@@ -169,45 +117,30 @@ func (s *Schematic) setPartNumbers() error {
 	return nil
 }
 
-func partNumbersFromRow(row []*Point) ([]*PartNumber, error) {
-	rowStr := ""
-	for _, curr := range row {
-		rowStr += string(curr.value)
-	}
-	re := regexp.MustCompile("\\D+")
-	splits := re.Split(rowStr, -1)
-	var numbers []string
-	for _, curr := range splits {
-		if len(curr) > 0 {
-			numbers = append(numbers, curr)
-		}
-	}
-
+// Returns the part numbers in row `y` of `g`: each run of consecutive
+// digits, with the points it is made of.
+func partNumbersFromRow(g *grid.Grid[rune], y int) ([]*PartNumber, error) {
 	var partNumbers []*PartNumber
-	for _, num := range numbers {
-		index := strings.Index(rowStr, num)
-		nInt, err := strconv.Atoi(num)
+	for _, run := range g.RowRuns(y, isDigit) {
+		digits := g.Row(y)[run.Start.X : run.Start.X+run.Len]
+		nInt, err := strconv.Atoi(string(digits))
 		if err != nil {
 			return nil, errors.New("could not read number")
 		}
 
-		pn := &PartNumber{
+		partNumbers = append(partNumbers, &PartNumber{
 			Number:  nInt,
-			points:  row[index : index+len(num)],
+			points:  run.Positions(),
 			isValid: false,
-		}
-
-		partNumbers = append(partNumbers, pn)
+		})
 	}
 
 	return partNumbers, nil
 }
 
+// Returns the part numbers adjacent to a symbol, which is none if the
+// schematic has no numbers.
 func (s *Schematic) ValidPartNumbers() ([]*PartNumber, error) {
-	if len(s.partNumbers) == 0 {
-		return nil, errors.New("part numbers not yet determined for schematic")
-	}
-
 	return lo.Filter(
 		s.partNumbers,
 		func(pn *PartNumber, i int) bool {
@@ -216,34 +149,35 @@ func (s *Schematic) ValidPartNumbers() ([]*PartNumber, error) {
 	), nil
 }
 
-// Build a new Schematic from the input file
-func NewSchematic() (*Schematic, error) {
-	scanner, file := fileScanner()
-	defer file.Close()
-
-	var points [][]*Point
-	y := 0
-
-	for scanner.Scan() {
-		var linePoints []*Point
-		line := scanner.Text()
-		for x, char := range line {
-			linePoints = append(linePoints, &Point{x: x, y: y, value: char})
-		}
+// Parse builds a Schematic from the lines read from `r`. Rows may have
+// different lengths, and may end with "\r\n" as well as "\n".
+func Parse(r io.Reader) (*Schematic, error) {
+	return ParseWith(r, AnySymbol)
+}
 
-		points = append(points, linePoints)
-		y += 1
+// ParseWith builds a Schematic from the lines read from `r`, as Parse
+// does, where only the points in `symbols` count as symbols.
+func ParseWith(r io.Reader, symbols SymbolClass) (*Schematic, error) {
+	g, err := grid.ParseRunes(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading schematic: %w", err)
 	}
 
 	schematic := &Schematic{
-		points:      points,
+		grid:        g,
 		partNumbers: []*PartNumber{},
+		symbols:     symbols,
 	}
 
-	err := schematic.setPartNumbers()
+	err = schematic.setPartNumbers()
 	if err != nil {
 		return nil, err
 	}
 
 	return schematic, nil
 }
+
+// ParseString builds a Schematic from `s`, as Parse does.
+func ParseString(s string) (*Schematic, error) {
+	return Parse(strings.NewReader(s))
+}
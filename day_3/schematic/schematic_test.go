@@ -0,0 +1,220 @@
+package schematic
+
+import (
+	"errors"
+	"github.com/ubermensch/advent_of_code_2023/grid"
+	"math/rand/v2"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestPartNumbersFromRow(t *testing.T) {
+	rows := map[string][][2]int{
+		// number, x of its first digit
+		"12...12":  {{12, 0}, {12, 5}},
+		"1...12":   {{1, 0}, {12, 4}},
+		"12...1":   {{12, 0}, {1, 5}},
+		"5*5":      {{5, 0}, {5, 2}},
+		"..7":      {{7, 2}},
+		"........": nil,
+		"0042#42.": {{42, 0}, {42, 5}},
+	}
+
+	for row, expected := range rows {
+		pns, err := partNumbersFromRow(grid.New([][]rune{[]rune(row)}), 0)
+		if err != nil {
+			t.Fatalf("[TestPartNumbersFromRow] unexpected error '%s'", err.Error())
+		}
+		if len(pns) != len(expected) {
+			t.Fatalf("[TestPartNumbersFromRow] for %q expected %d part numbers, actual %d", row, len(expected), len(pns))
+		}
+		for i, pn := range pns {
+			if pn.Number != expected[i][0] || pn.points[0].X != expected[i][1] {
+				t.Fatalf("[TestPartNumbersFromRow] for %q expected %d at %d, actual %s", row, expected[i][0], expected[i][1], pn.ToString())
+			}
+		}
+	}
+}
+
+// Brute-force reference answers for a schematic: every digit run is
+// checked cell by cell against all 8 neighbours of each of its digits.
+func referenceAnswers(lines []string) (int, int) {
+	at := func(x, y int) byte {
+		if y < 0 || y >= len(lines) || x < 0 || x >= len(lines[y]) {
+			return '.'
+		}
+		return lines[y][x]
+	}
+	isDigit := func(c byte) bool { return c >= '0' && c <= '9' }
+
+	sum := 0
+	// the numbers adjacent to each '*', by position
+	gearNumbers := map[[2]int][]int{}
+	for y, line := range lines {
+		for x := 0; x < len(line); x++ {
+			if !isDigit(line[x]) || (x > 0 && isDigit(line[x-1])) {
+				continue
+			}
+			end, n := x, 0
+			for end < len(line) && isDigit(line[end]) {
+				n = 10*n + int(line[end]-'0')
+				end++
+			}
+
+			valid := false
+			stars := map[[2]int]bool{}
+			for cx := x; cx < end; cx++ {
+				for dy := -1; dy <= 1; dy++ {
+					for dx := -1; dx <= 1; dx++ {
+						c := at(cx+dx, y+dy)
+						if c != '.' && !isDigit(c) {
+							valid = true
+						}
+						if c == '*' {
+							stars[[2]int{cx + dx, y + dy}] = true
+						}
+					}
+				}
+			}
+			if valid {
+				sum += n
+			}
+			for star := range stars {
+				gearNumbers[star] = append(gearNumbers[star], n)
+			}
+		}
+	}
+
+	ratios := 0
+	for _, numbers := range gearNumbers {
+		if len(numbers) == 2 {
+			ratios += numbers[0] * numbers[1]
+		}
+	}
+	return sum, ratios
+}
+
+func randomSchematic(rng *rand.Rand) []string {
+	// mostly dots and digits, so numbers repeat on a row and sit next to
+	// each other's symbols
+	const cells = "........0123456789112*#*+$"
+	lines := make([]string, 1+rng.IntN(8))
+	width := 1 + rng.IntN(12)
+	for y := range lines {
+		var line strings.Builder
+		for x := 0; x < width; x++ {
+			line.WriteByte(cells[rng.IntN(len(cells))])
+		}
+		lines[y] = line.String()
+	}
+	return lines
+}
+
+func TestSchematic_reference(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 2023))
+	for i := 0; i < 2000; i++ {
+		lines := randomSchematic(rng)
+		doc := strings.Join(lines, "\n")
+
+		s, err := ParseString(doc)
+		if err != nil {
+			t.Fatalf("[TestSchematic_reference] unexpected error '%s' for:\n%s", err.Error(), doc)
+		}
+		valid, err := s.ValidPartNumbers()
+		if err != nil {
+			t.Fatalf("[TestSchematic_reference] unexpected error '%s'", err.Error())
+		}
+		gears, err := s.Gears()
+		if err != nil {
+			t.Fatalf("[TestSchematic_reference] unexpected error '%s'", err.Error())
+		}
+
+		sum, ratios := 0, 0
+		for _, pn := range valid {
+			sum += pn.Number
+		}
+		for _, gear := range gears {
+			ratios += gear.Ratio
+		}
+
+		expectedSum, expectedRatios := referenceAnswers(lines)
+		if sum != expectedSum || ratios != expectedRatios {
+			t.Fatalf("[TestSchematic_reference] for schematic\n%s\nexpected sum %d and ratios %d, actual %d and %d",
+				doc, expectedSum, expectedRatios, sum, ratios)
+		}
+	}
+}
+
+// The sum of the valid part numbers of the schematic `doc`.
+func validSum(t *testing.T, doc string) int {
+	s, err := ParseString(doc)
+	if err != nil {
+		t.Fatalf("unexpected error '%s'", err.Error())
+	}
+	valid, err := s.ValidPartNumbers()
+	if err != nil {
+		t.Fatalf("unexpected error '%s'", err.Error())
+	}
+
+	sum := 0
+	for _, pn := range valid {
+		sum += pn.Number
+	}
+	return sum
+}
+
+func TestParse(t *testing.T) {
+	lf := "467..114..\n...*......\n..35..633.\n......#..."
+	crlf := strings.ReplaceAll(lf, "\n", "\r\n") + "\r\n"
+	// 467 and 35 touch the '*', 633 the '#'
+	if sum := validSum(t, lf); sum != 467+35+633 {
+		t.Fatalf("[TestParse] expected %d, actual %d", 467+35+633, sum)
+	}
+	// a trailing '\r' is not a symbol next to 114
+	if sum := validSum(t, crlf); sum != 467+35+633 {
+		t.Fatalf("[TestParse] expected %d with CRLF line endings, actual %d", 467+35+633, sum)
+	}
+
+	// ragged rows: the symbols past the end of shorter rows still count,
+	// and numbers next to the end of a row are not adjacent to the next
+	ragged := strings.Join([]string{
+		"1",
+		"..........*",
+		"..........12",
+		"5",
+		"..7",
+		"#",
+	}, "\n")
+	if sum := validSum(t, ragged); sum != 12 {
+		t.Fatalf("[TestParse] expected 12 with ragged rows, actual %d", sum)
+	}
+}
+
+func TestParse_errors(t *testing.T) {
+	errRead := errors.New("read failed")
+	if _, err := Parse(iotest.ErrReader(errRead)); !errors.Is(err, errRead) {
+		t.Fatalf("[TestParse_errors] expected %v, actual %v", errRead, err)
+	}
+}
+
+func TestParse_noNumbers(t *testing.T) {
+	s, err := ParseString("...\n.#.")
+	if err != nil {
+		t.Fatalf("[TestParse_noNumbers] unexpected error '%s'", err.Error())
+	}
+	valid, err := s.ValidPartNumbers()
+	if err != nil {
+		t.Fatalf("[TestParse_noNumbers] unexpected error '%s'", err.Error())
+	}
+	if len(valid) != 0 {
+		t.Fatalf("[TestParse_noNumbers] expected no valid part numbers, actual %d", len(valid))
+	}
+	gears, err := s.Gears()
+	if err != nil {
+		t.Fatalf("[TestParse_noNumbers] unexpected error '%s'", err.Error())
+	}
+	if len(gears) != 0 {
+		t.Fatalf("[TestParse_noNumbers] expected no gears, actual %d", len(gears))
+	}
+}
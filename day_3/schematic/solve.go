@@ -0,0 +1,58 @@
+package schematic
+
+import (
+	"github.com/ubermensch/advent_of_code_2023/solver"
+	"io"
+)
+
+// Solver solves the day 3 puzzle.
+var Solver = &solver.Day{
+	Number: 3,
+	Part1:  part1,
+	Part2:  part2,
+}
+
+func part1(r io.Reader) (solver.Result, error) {
+	s, err := Parse(r)
+	if err != nil {
+		return solver.Result{}, err
+	}
+	valid, err := s.ValidPartNumbers()
+	if err != nil {
+		return solver.Result{}, err
+	}
+
+	sum := 0
+	for _, curr := range valid {
+		sum += curr.Number
+	}
+
+	return solver.Result{
+		Answer: sum,
+		Stats: solver.Stats{
+			"part_numbers":       len(s.partNumbers),
+			"valid_part_numbers": len(valid),
+		},
+	}, nil
+}
+
+func part2(r io.Reader) (solver.Result, error) {
+	s, err := Parse(r)
+	if err != nil {
+		return solver.Result{}, err
+	}
+	gears, err := s.Gears()
+	if err != nil {
+		return solver.Result{}, err
+	}
+
+	sum := 0
+	for _, gear := range gears {
+		sum += gear.Ratio
+	}
+
+	return solver.Result{
+		Answer: sum,
+		Stats:  solver.Stats{"gears": len(gears)},
+	}, nil
+}
@@ -0,0 +1,16 @@
+package schematic
+
+import (
+	"github.com/ubermensch/advent_of_code_2023/solver"
+	"github.com/ubermensch/advent_of_code_2023/solver/solvertest"
+	"testing"
+)
+
+func TestSolver_examples(t *testing.T) {
+	solvertest.Run(t, Solver, []solvertest.Example{
+		{File: "example.txt", Part: solver.One, Want: 4361},
+		{File: "example.txt", Part: solver.Two, Want: 467835},
+		{File: "no_numbers.txt", Part: solver.One, Want: 0},
+		{File: "no_numbers.txt", Part: solver.Two, Want: 0},
+	})
+}
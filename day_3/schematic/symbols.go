@@ -0,0 +1,77 @@
+package schematic
+
+import (
+	"cmp"
+	"slices"
+)
+
+// SymbolClass decides which values of the schematic count as symbols.
+type SymbolClass func(value rune) bool
+
+// AnySymbol is the symbol class of the puzzle: anything but a '.' or a
+// digit.
+var AnySymbol SymbolClass = isSymbol
+
+// RuneSet returns the symbol class of the given runes only, such as
+// RuneSet('*') for gears.
+func RuneSet(symbols ...rune) SymbolClass {
+	return func(value rune) bool {
+		return slices.Contains(symbols, value)
+	}
+}
+
+// Symbol is a symbol found in the schematic.
+type Symbol struct {
+	X     int // x-position in the Schematic line (0-indexed)
+	Y     int // y-position in the Schematic, the line number (0-indexed)
+	Value rune
+}
+
+// Returns the points adjacent to the part number that are in `class`,
+// in reading order.
+func (s *Schematic) symbolsAdjacentTo(pn *PartNumber, class SymbolClass) ([]Symbol, error) {
+	adjacent, err := s.adjacentTo(pn)
+	if err != nil {
+		return nil, err
+	}
+
+	var symbols []Symbol
+	for _, point := range adjacent {
+		if value, _ := s.grid.Get(point); class(value) {
+			symbols = append(symbols, Symbol{X: point.X, Y: point.Y, Value: value})
+		}
+	}
+	slices.SortFunc(symbols, func(a, b Symbol) int {
+		return cmp.Or(cmp.Compare(a.Y, b.Y), cmp.Compare(a.X, b.X))
+	})
+	return symbols, nil
+}
+
+// SymbolsAdjacentTo returns the symbols the part number is adjacent to,
+// in reading order. A part number is valid if there is at least one.
+func (s *Schematic) SymbolsAdjacentTo(pn *PartNumber) ([]Symbol, error) {
+	return s.symbolsAdjacentTo(pn, s.symbols)
+}
+
+// PartNumbersAdjacentTo returns the part numbers adjacent to at least one
+// symbol of `class`, in reading order, whichever symbols the schematic was
+// parsed with.
+func (s *Schematic) PartNumbersAdjacentTo(class SymbolClass) ([]*PartNumber, error) {
+	var adjacent []*PartNumber
+	for _, pn := range s.partNumbers {
+		symbols, err := s.symbolsAdjacentTo(pn, class)
+		if err != nil {
+			return nil, err
+		}
+		if len(symbols) > 0 {
+			adjacent = append(adjacent, pn)
+		}
+	}
+	return adjacent, nil
+}
+
+// PartNumbers returns every part number in the schematic, valid or not,
+// in reading order.
+func (s *Schematic) PartNumbers() []*PartNumber {
+	return slices.Clone(s.partNumbers)
+}
@@ -0,0 +1,106 @@
+package schematic
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func exampleSchematic(t *testing.T, symbols SymbolClass) *Schematic {
+	file, err := os.Open("testdata/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	s, err := ParseWith(file, symbols)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func numbers(pns []*PartNumber) []int {
+	var nums []int
+	for _, pn := range pns {
+		nums = append(nums, pn.Number)
+	}
+	return nums
+}
+
+func TestSchematic_PartNumbersAdjacentTo(t *testing.T) {
+	s := exampleSchematic(t, AnySymbol)
+
+	valid, err := s.ValidPartNumbers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	adjacent, err := s.PartNumbersAdjacentTo(AnySymbol)
+	if err != nil {
+		t.Fatalf("[TestSchematic_PartNumbersAdjacentTo] unexpected error '%s'", err.Error())
+	}
+	if !reflect.DeepEqual(numbers(adjacent), numbers(valid)) {
+		t.Fatalf("[TestSchematic_PartNumbersAdjacentTo] expected %v, actual %v", numbers(valid), numbers(adjacent))
+	}
+
+	for class, expected := range map[string][]int{
+		"*":  {467, 35, 617, 755, 598},
+		"#$": {633, 664},
+		"@":  nil,
+	} {
+		adjacent, err := s.PartNumbersAdjacentTo(RuneSet([]rune(class)...))
+		if err != nil {
+			t.Fatalf("[TestSchematic_PartNumbersAdjacentTo] unexpected error '%s'", err.Error())
+		}
+		if !reflect.DeepEqual(numbers(adjacent), expected) {
+			t.Fatalf("[TestSchematic_PartNumbersAdjacentTo] for %q expected %v, actual %v", class, expected, numbers(adjacent))
+		}
+	}
+}
+
+func TestSchematic_SymbolsAdjacentTo(t *testing.T) {
+	s := exampleSchematic(t, AnySymbol)
+	pns := s.PartNumbers()
+
+	// 467 touches the gear, 114 touches nothing
+	symbols, err := s.SymbolsAdjacentTo(pns[0])
+	if err != nil {
+		t.Fatalf("[TestSchematic_SymbolsAdjacentTo] unexpected error '%s'", err.Error())
+	}
+	if !reflect.DeepEqual(symbols, []Symbol{{X: 3, Y: 1, Value: '*'}}) {
+		t.Fatalf("[TestSchematic_SymbolsAdjacentTo] expected the '*' at 3,1, actual %v", symbols)
+	}
+	if symbols, _ := s.SymbolsAdjacentTo(pns[1]); len(symbols) != 0 {
+		t.Fatalf("[TestSchematic_SymbolsAdjacentTo] expected no symbols next to 114, actual %v", symbols)
+	}
+}
+
+func TestParseWith(t *testing.T) {
+	// only '*' counts, so 633 and 592 and 664 are no longer valid
+	s := exampleSchematic(t, RuneSet('*'))
+	valid, err := s.ValidPartNumbers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []int{467, 35, 617, 755, 598}
+	if !reflect.DeepEqual(numbers(valid), expected) {
+		t.Fatalf("[TestParseWith] expected %v, actual %v", expected, numbers(valid))
+	}
+	if symbols, _ := s.SymbolsAdjacentTo(s.PartNumbers()[3]); len(symbols) != 0 {
+		t.Fatalf("[TestParseWith] expected the '#' next to 633 not to count, actual %v", symbols)
+	}
+}
+
+func TestRuneSet(t *testing.T) {
+	class := RuneSet('*', -1, '\U0010FFFF')
+	for _, value := range []rune{'*', -1, '\U0010FFFF'} {
+		if !class(value) {
+			t.Fatalf("[TestRuneSet] expected %q to be in the class", value)
+		}
+	}
+	for _, value := range []rune{'#', '.', '7', -2} {
+		if class(value) {
+			t.Fatalf("[TestRuneSet] expected %q not to be in the class", value)
+		}
+	}
+}
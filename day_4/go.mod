@@ -1,3 +0,0 @@
-module day_4
-
-go 1.21.7
@@ -0,0 +1,150 @@
+package scratchcard
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/samber/lo"
+	"github.com/ubermensch/advent_of_code_2023/bitset"
+	"github.com/ubermensch/advent_of_code_2023/pool"
+	"io"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// Card is a scratchcard: its ID, its winning numbers and the numbers we
+// have, each between 0 and MaxNumber.
+type Card struct {
+	ID      int
+	Winning []int
+	Have    []int
+}
+
+// MaxNumber is the largest number a card may have. The puzzle's numbers
+// have at most two digits, so a card's numbers fit in a fixed size mask.
+const MaxNumber = 99
+
+// ParseError is returned when a card cannot be parsed. Line is 1-based.
+type ParseError struct {
+	Line int
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Parses a space separated list of numbers, such as "41 48 83", which
+// must be between 0 and MaxNumber and must not repeat any number. `what`
+// names the list in errors.
+func parseNumbers(numStr string, what string) ([]int, error) {
+	var nums []int
+	var seen bitset.Set
+	for _, field := range strings.Fields(numStr) {
+		num, err := strconv.Atoi(field)
+		if err != nil || num < 0 {
+			return nil, fmt.Errorf("invalid number %q in %s", field, what)
+		}
+		if num > MaxNumber {
+			return nil, fmt.Errorf("number %d in %s is above the maximum of %d", num, what, MaxNumber)
+		}
+		if !seen.Add(num) {
+			return nil, fmt.Errorf("duplicate number %d in %s", num, what)
+		}
+		nums = append(nums, num)
+	}
+	return nums, nil
+}
+
+// ParseCard parses a card such as "Card 1: 41 48 83 86 17 | 83 86  6 31".
+func ParseCard(line string) (*Card, error) {
+	label, numbers, ok := strings.Cut(line, ":")
+	if !ok {
+		return nil, errors.New("missing ':' after the card ID")
+	}
+	idStr, ok := strings.CutPrefix(label, "Card")
+	if !ok {
+		return nil, errors.New("missing \"Card\"")
+	}
+	id, err := strconv.Atoi(strings.TrimSpace(idStr))
+	if err != nil {
+		return nil, fmt.Errorf("invalid card ID %q", strings.TrimSpace(idStr))
+	}
+	winningStr, haveStr, ok := strings.Cut(numbers, "|")
+	if !ok {
+		return nil, errors.New("missing '|' between the winning numbers and the numbers we have")
+	}
+
+	winning, err := parseNumbers(winningStr, "winning numbers")
+	if err != nil {
+		return nil, err
+	}
+	have, err := parseNumbers(haveStr, "numbers we have")
+	if err != nil {
+		return nil, err
+	}
+	return &Card{ID: id, Winning: winning, Have: have}, nil
+}
+
+// Parse reads the cards from `r`, one per line, in order. The cards are
+// parsed concurrently, and the first malformed one is reported as a
+// *ParseError.
+func Parse(r io.Reader) ([]*Card, error) {
+	scanner := bufio.NewScanner(r)
+
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	cards, err := pool.Map(context.Background(), Workers, lo.Range(len(lines)), func(i int) (*Card, error) {
+		return ParseCard(lines[i])
+	})
+	var perr *pool.Error
+	if errors.As(err, &perr) {
+		return nil, &ParseError{Line: perr.Index + 1, Err: perr.Err}
+	}
+	return cards, err
+}
+
+// A set of card numbers, one bit per possible number.
+type numberMask [MaxNumber/64 + 1]uint64
+
+func maskOf(nums []int) numberMask {
+	var mask numberMask
+	for _, n := range nums {
+		mask[n/64] |= 1 << (n % 64)
+	}
+	return mask
+}
+
+// Matches returns how many of the numbers we have are winning numbers:
+// the size of the intersection of the masks of both lists, which needs no
+// allocation or search.
+func (c *Card) Matches() int {
+	winning, have := maskOf(c.Winning), maskOf(c.Have)
+	count := 0
+	for i := range winning {
+		count += bits.OnesCount64(winning[i] & have[i])
+	}
+	return count
+}
+
+// Score returns the points the card is worth: 1 for the first match,
+// doubled for every match after it.
+func (c *Card) Score() int {
+	matches := c.Matches()
+	if matches == 0 {
+		return 0
+	}
+	return 1 << (matches - 1)
+}
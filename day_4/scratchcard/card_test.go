@@ -0,0 +1,181 @@
+package scratchcard
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseCard(t *testing.T) {
+	card, err := ParseCard("Card  12: 41 48 83 86 17 | 83 86  6 31 17  9 48 53")
+	if err != nil {
+		t.Fatalf("[TestParseCard] unexpected error '%s'", err.Error())
+	}
+	expected := Card{
+		ID:      12,
+		Winning: []int{41, 48, 83, 86, 17},
+		Have:    []int{83, 86, 6, 31, 17, 9, 48, 53},
+	}
+	if !reflect.DeepEqual(*card, expected) {
+		t.Fatalf("[TestParseCard] expected %+v, actual %+v", expected, *card)
+	}
+	if card.Matches() != 4 {
+		t.Fatalf("[TestParseCard] expected 4 matches, actual %d", card.Matches())
+	}
+
+	// the matches follow changes to the numbers
+	card.Have = []int{41, 48, 83, 86, 17, 99}
+	if card.Matches() != 5 {
+		t.Fatalf("[TestParseCard] expected 5 matches after changing Have, actual %d", card.Matches())
+	}
+	card.Winning = []int{99, 41}
+	if card.Matches() != 2 {
+		t.Fatalf("[TestParseCard] expected 2 matches after changing Winning, actual %d", card.Matches())
+	}
+}
+
+func TestParseCard_errors(t *testing.T) {
+	lines := map[string]string{
+		"Card 1 41 48 | 83":                "missing ':' after the card ID",
+		"Crad 1: 41 48 | 83":               "missing \"Card\"",
+		"Card one: 41 48 | 83":             "invalid card ID \"one\"",
+		"Card 1: 41 48 83":                 "missing '|' between the winning numbers and the numbers we have",
+		"Card 1: 41 4x | 83":               "invalid number \"4x\" in winning numbers",
+		"Card 1: 41 48 | 83 -1":            "invalid number \"-1\" in numbers we have",
+		"Card 1: 41 48 41 | 83":            "duplicate number 41 in winning numbers",
+		"Card 1: 41 48 | 83 9 83 ":         "duplicate number 83 in numbers we have",
+		"Card 1: 100 | 1":                  "number 100 in winning numbers is above the maximum of 99",
+		"Card 1: 41 | 100000000000":        "number 100000000000 in numbers we have is above the maximum of 99",
+		"Card 1: 9223372036854775807 | 1":  "number 9223372036854775807 in winning numbers is above the maximum of 99",
+		"Card 1: 99999999999999999999 | 1": "invalid number \"99999999999999999999\" in winning numbers",
+	}
+
+	for line, expected := range lines {
+		_, err := ParseCard(line)
+		if err == nil || err.Error() != expected {
+			t.Fatalf("[TestParseCard_errors] for %q expected error %q, actual %v", line, expected, err)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	doc := strings.Join([]string{
+		"Card 1: 41 48 | 83 86",
+		"Card 2: 13 32 | 61 30",
+		"Card 3: 1 21 | 69 69",
+		"Card 4: 41 92 |",
+	}, "\n")
+
+	_, err := Parse(strings.NewReader(doc))
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Line != 3 {
+		t.Fatalf("[TestParse] expected a parse error on line 3, actual %v", err)
+	}
+	if err.Error() != "line 3: duplicate number 69 in numbers we have" {
+		t.Fatalf("[TestParse] unexpected error message %q", err.Error())
+	}
+
+	// a number too large for a card is a parse error, not a huge allocation
+	_, err = Parse(strings.NewReader(strings.Replace(doc, "13 32", "13 9223372036854775807", 1)))
+	if !errors.As(err, &perr) || perr.Line != 2 {
+		t.Fatalf("[TestParse] expected a parse error on line 2, actual %v", err)
+	}
+
+	cards, err := Parse(strings.NewReader(strings.Replace(doc, "69 69", "69", 1)))
+	if err != nil {
+		t.Fatalf("[TestParse] unexpected error '%s'", err.Error())
+	}
+	for i, card := range cards {
+		if card.ID != i+1 {
+			t.Fatalf("[TestParse] expected card %d, actual %d", i+1, card.ID)
+		}
+	}
+	if len(cards[3].Have) != 0 {
+		t.Fatalf("[TestParse] expected card 4 to have no numbers, actual %v", cards[3].Have)
+	}
+}
+
+func TestCard_MatchesAndScore(t *testing.T) {
+	cards := []struct {
+		line    string
+		matches int
+		score   int
+	}{
+		{"Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53", 4, 8},
+		{"Card 2: 13 32 20 16 61 | 61 30 68 82 17 32 24 19", 2, 2},
+		{"Card 3:  1 21 53 59 44 | 69 82 63 72 16 21 14  1", 2, 2},
+		{"Card 4: 41 92 73 84 69 | 59 84 76 51 58  5 54 83", 1, 1},
+		{"Card 5: 87 83 26 28 32 | 88 30 70 12 93 22 82 36", 0, 0},
+		{"Card 6: 1 2 3 4 5 6 7 8 9 10 | 1 2 3 4 5 6 7 8 9 10", 10, 512},
+		{"Card 7: | 1 2", 0, 0},
+	}
+
+	for _, c := range cards {
+		card, err := ParseCard(c.line)
+		if err != nil {
+			t.Fatalf("[TestCard_MatchesAndScore] unexpected error '%s'", err.Error())
+		}
+		if card.Matches() != c.matches || card.Score() != c.score {
+			t.Fatalf("[TestCard_MatchesAndScore] for %q expected %d matches and score %d, actual %d and %d",
+				c.line, c.matches, c.score, card.Matches(), card.Score())
+		}
+	}
+}
+
+// The original match count: a search of the winning numbers for each
+// number we have.
+func referenceMatches(c *Card) int {
+	count := 0
+	for _, num := range c.Have {
+		if slices.Contains(c.Winning, num) {
+			count += 1
+		}
+	}
+	return count
+}
+
+// The cards of the real input, or of the example if it is not available.
+// Every card's matches are checked against the reference first.
+func benchmarkCards(b *testing.B) []*Card {
+	input, err := os.ReadFile("../input.txt")
+	if err != nil {
+		if input, err = os.ReadFile("testdata/example.txt"); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	cards, err := Parse(bytes.NewReader(input))
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, card := range cards {
+		if card.Matches() != referenceMatches(card) {
+			b.Fatalf("card %d: expected %d matches, actual %d", card.ID, referenceMatches(card), card.Matches())
+		}
+	}
+	return cards
+}
+
+// Counts the matches of every card, parsed before the timer starts.
+func benchmarkMatches(b *testing.B, matches func(*Card) int) {
+	cards := benchmarkCards(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, card := range cards {
+			matches(card)
+		}
+	}
+}
+
+func BenchmarkCard_Matches(b *testing.B) {
+	benchmarkMatches(b, (*Card).Matches)
+}
+
+func BenchmarkReferenceMatches(b *testing.B) {
+	benchmarkMatches(b, referenceMatches)
+}
@@ -0,0 +1,56 @@
+package scratchcard
+
+import (
+	"fmt"
+	"github.com/samber/lo"
+	"io"
+)
+
+// Workers is the number of cards parsed concurrently. If not positive,
+// runtime.GOMAXPROCS(0) is used.
+var Workers = 0
+
+// Sum reads the scratchcards from `r`, one per line, and returns
+// the total of their scores.
+func Sum(r io.Reader) (int, error) {
+	cards, err := Parse(r)
+	if err != nil {
+		return 0, err
+	}
+	return lo.SumBy(cards, (*Card).Score), nil
+}
+
+// TotalCards reads the scratchcards from `r`, one per line, and returns
+// how many cards there are once every card has won its copies. A card
+// with N matches wins one copy of each of the N cards after it, for each
+// copy of it there is.
+func TotalCards(r io.Reader) (int, error) {
+	cards, err := Parse(r)
+	if err != nil {
+		return 0, err
+	}
+	for i, c := range cards {
+		if c.ID != i+1 {
+			return 0, &ParseError{Line: i + 1, Err: fmt.Errorf("expected card %d, found card %d", i+1, c.ID)}
+		}
+	}
+
+	// Rather than adding the copies won by a card to each of the cards it
+	// wins, which is quadratic for cards with many matches, the change in
+	// the number of copies won is recorded where a run of won cards starts
+	// and ends, and the copies of each card are the running total.
+	changes := make([]int, len(cards)+1)
+	total, won := 0, 0
+	for i, c := range cards {
+		won += changes[i]
+		copies := 1 + won
+		total += copies
+
+		last := min(i+c.Matches(), len(cards)-1)
+		if last > i {
+			changes[i+1] += copies
+			changes[last+1] -= copies
+		}
+	}
+	return total, nil
+}
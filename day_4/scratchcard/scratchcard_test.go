@@ -0,0 +1,72 @@
+package scratchcard
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+// A document of cards with the given numbers of matches.
+func cardsDoc(matches []int) string {
+	var doc strings.Builder
+	for i, m := range matches {
+		var have []string
+		for n := 1; n <= 10; n++ {
+			if n <= m {
+				have = append(have, fmt.Sprint(n))
+			} else {
+				have = append(have, fmt.Sprint(50+n))
+			}
+		}
+		fmt.Fprintf(&doc, "Card %3d: 1 2 3 4 5 6 7 8 9 10 | %s\n", i+1, strings.Join(have, " "))
+	}
+	return doc.String()
+}
+
+// Counts the cards by processing every copy one by one.
+func referenceTotalCards(matches []int) int {
+	copies := make([]int, len(matches))
+	total := 0
+	for i := range matches {
+		copies[i]++
+		total += copies[i]
+		for c := 0; c < copies[i]; c++ {
+			for j := i + 1; j <= i+matches[i] && j < len(matches); j++ {
+				copies[j]++
+			}
+		}
+	}
+	return total
+}
+
+func TestTotalCards(t *testing.T) {
+	rng := rand.New(rand.NewPCG(4, 2023))
+	for i := 0; i < 200; i++ {
+		matches := make([]int, 1+rng.IntN(12))
+		for j := range matches {
+			matches[j] = rng.IntN(min(11, len(matches)-j))
+		}
+
+		actual, err := TotalCards(strings.NewReader(cardsDoc(matches)))
+		if err != nil {
+			t.Fatalf("[TestTotalCards] unexpected error '%s'", err.Error())
+		}
+		if expected := referenceTotalCards(matches); actual != expected {
+			t.Fatalf("[TestTotalCards] for matches %v expected %d, actual %d", matches, expected, actual)
+		}
+	}
+}
+
+func TestTotalCards_errors(t *testing.T) {
+	for _, doc := range []string{
+		"Card 1: 1 | 1\nCard 3: 1 | 1",
+		"Card x: 1 | 1",
+		"Crad 1: 1 | 1",
+		"Card 1: 1 1",
+	} {
+		if _, err := TotalCards(strings.NewReader(doc)); err == nil {
+			t.Fatalf("[TestTotalCards_errors] expected an error for %q", doc)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package scratchcard
+
+import (
+	"github.com/ubermensch/advent_of_code_2023/solver"
+	"io"
+)
+
+// Solver solves the day 4 puzzle.
+var Solver = &solver.Day{
+	Number: 4,
+	Part1:  part1,
+	Part2:  part2,
+}
+
+func part1(r io.Reader) (solver.Result, error) {
+	sum, err := Sum(r)
+	return solver.Result{Answer: sum}, err
+}
+
+func part2(r io.Reader) (solver.Result, error) {
+	total, err := TotalCards(r)
+	return solver.Result{Answer: total}, err
+}
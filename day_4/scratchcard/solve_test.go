@@ -0,0 +1,14 @@
+package scratchcard
+
+import (
+	"github.com/ubermensch/advent_of_code_2023/solver"
+	"github.com/ubermensch/advent_of_code_2023/solver/solvertest"
+	"testing"
+)
+
+func TestSolver_examples(t *testing.T) {
+	solvertest.Run(t, Solver, []solvertest.Example{
+		{File: "example.txt", Part: solver.One, Want: 13},
+		{File: "example.txt", Part: solver.Two, Want: 30},
+	})
+}
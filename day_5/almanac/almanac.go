@@ -0,0 +1,310 @@
+package almanac
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/samber/lo"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type lookupRange struct {
+	destRange   int64
+	sourceRange int64
+	rangeLength int64
+}
+
+type Map struct {
+	// source and target are 'seed', 'soil', 'water', 'location' etc.
+	source string
+	target string
+	// the partial lookup map
+	lookup []*lookupRange
+}
+
+// Find returns the value that `k` maps to. A lookup range of length n
+// covers the n source values from its start, so its end is exclusive;
+// values outside every lookup range map to themselves.
+func (m *Map) Find(k int64) int64 {
+	for _, currRange := range m.lookup {
+		// if k is in the range...
+		if k >= currRange.sourceRange && k < currRange.sourceRange+currRange.rangeLength {
+			// ...return the value in the destRange in the same position offset
+			// from beginning of source range.
+			return k - currRange.sourceRange + currRange.destRange
+		}
+	}
+
+	return k
+}
+
+// A half-open interval [start, end) of values, e.g. a range of seeds.
+type valueRange struct {
+	start int64
+	end   int64
+}
+
+// FindRanges maps every value of the given ranges through the Map, in the
+// same way as Find. Ranges that straddle the edges of a lookup range are
+// split, so the result may hold more ranges than were given.
+func (m *Map) FindRanges(ranges []valueRange) []valueRange {
+	var mapped []valueRange
+	pending := ranges
+	for _, currRange := range m.lookup {
+		srcStart := currRange.sourceRange
+		srcEnd := currRange.sourceRange + currRange.rangeLength
+		offset := currRange.destRange - currRange.sourceRange
+
+		var unmapped []valueRange
+		for _, r := range pending {
+			// the part before the lookup range is left for the next one...
+			if r.start < srcStart {
+				unmapped = append(unmapped, valueRange{r.start, min(r.end, srcStart)})
+			}
+			// ...the overlapping part is shifted into the destination range...
+			if start, end := max(r.start, srcStart), min(r.end, srcEnd); start < end {
+				mapped = append(mapped, valueRange{start + offset, end + offset})
+			}
+			// ...and the part after it is also left for the next one.
+			if r.end > srcEnd {
+				unmapped = append(unmapped, valueRange{max(r.start, srcEnd), r.end})
+			}
+		}
+		pending = unmapped
+	}
+
+	// values not covered by any lookup range map to themselves
+	return append(mapped, pending...)
+}
+
+// Returns a pointer to a new `Map` struct
+func NewMap(source string, target string, lookupRanges [][]int64) *Map {
+	lookup := lo.Map(
+		lookupRanges,
+		func(curr []int64, i int) *lookupRange {
+			return &lookupRange{
+				destRange:   curr[0],
+				sourceRange: curr[1],
+				rangeLength: curr[2],
+			}
+		},
+	)
+
+	return &Map{
+		source: source,
+		target: target,
+		lookup: lookup,
+	}
+}
+
+// Parses the seeds line, such as "seeds: 79 14 55 13".
+func getSeeds(s string) ([]int64, error) {
+	label, seedStr, ok := strings.Cut(s, ":")
+	if !ok || label != "seeds" {
+		return nil, errors.New("expected a seeds line")
+	}
+	seeds, err := parseInts(seedStr)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse seeds: %w", err)
+	}
+	if len(seeds) == 0 {
+		return nil, errors.New("no seeds given")
+	}
+	return seeds, nil
+}
+
+// Parses a space separated list of numbers.
+func parseInts(s string) ([]int64, error) {
+	var ints []int64
+	for _, field := range strings.Fields(s) {
+		n, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", field)
+		}
+		ints = append(ints, n)
+	}
+	return ints, nil
+}
+
+// Reads the lookup ranges of the map whose header, such as
+// "seed-to-soil map:", is `mapLine`, up to the next blank line.
+func parseMapScanner(scanner *bufio.Scanner, mapLine string) (*Map, error) {
+	name, _, _ := strings.Cut(mapLine, " ")
+	source, target, ok := strings.Cut(name, "-to-")
+	if !ok || source == "" || target == "" {
+		return nil, fmt.Errorf("invalid map header %q", mapLine)
+	}
+
+	var lookupRanges [][]int64
+
+	var line string
+	for scanner.Scan() {
+		line = scanner.Text()
+		if line == "" {
+			break
+		}
+		destTargetPieces, err := parseInts(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s-to-%s map: %w", source, target, err)
+		}
+		if len(destTargetPieces) != 3 {
+			return nil, fmt.Errorf("%s-to-%s map: expected destination, source and length in %q", source, target, line)
+		}
+		lookupRanges = append(lookupRanges, destTargetPieces)
+	}
+
+	return NewMap(source, target, lookupRanges), nil
+}
+
+// Traverses the set of maps given, finding the location number
+// matching the given seed number
+func seedLocation(seed int64, maps []*Map) (int64, error) {
+	var location, currSource, currTarget int64
+	var sourceType, targetType string
+
+	currSource = seed
+	sourceType = "seed"
+
+	// traverse the maps, where target of current source
+	// becomes next source. Stop when we have found the location value.
+	for {
+		currMap, err := mapFrom(sourceType, maps)
+		if err != nil {
+			return 0, err
+		}
+		currTarget = currMap.Find(currSource)
+		targetType = currMap.target
+
+		if targetType == "location" {
+			location = currTarget
+			break
+		} else {
+			currSource = currTarget
+			sourceType = targetType
+		}
+	}
+
+	return location, nil
+}
+
+// Finds the map converting from the given source type
+func mapFrom(sourceType string, maps []*Map) (*Map, error) {
+	currMap, ok := lo.Find(maps, func(m *Map) bool {
+		return m.source == sourceType
+	})
+	if !ok {
+		return nil, errors.New("map for source not found: " + sourceType)
+	}
+	return currMap, nil
+}
+
+// Traverses the set of maps given, finding the ranges of location numbers
+// matching the given ranges of seed numbers
+func seedRangeLocations(seeds []valueRange, maps []*Map) ([]valueRange, error) {
+	currRanges := seeds
+	sourceType := "seed"
+	for sourceType != "location" {
+		currMap, err := mapFrom(sourceType, maps)
+		if err != nil {
+			return nil, err
+		}
+		currRanges = currMap.FindRanges(currRanges)
+		sourceType = currMap.target
+	}
+
+	return currRanges, nil
+}
+
+// Reads the almanac from `r`, returning its initial seeds and its maps.
+func parse(r io.Reader) ([]int64, []*Map, error) {
+	var seeds []int64
+	var maps []*Map
+
+	scanner := bufio.NewScanner(r)
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, errors.New("expected a seeds line")
+	}
+	line := scanner.Text()
+	seeds, err := getSeeds(line)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for scanner.Scan() {
+		line = scanner.Text()
+
+		// gap between map definitions
+		if line == "" {
+			continue
+		}
+
+		// start of a new map definition
+		if !strings.HasSuffix(line, "map:") {
+			return nil, nil, fmt.Errorf("expected a map header, found %q", line)
+		}
+		newMap, err := parseMapScanner(scanner, line)
+		if err != nil {
+			return nil, nil, err
+		}
+		maps = append(maps, newMap)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return seeds, maps, nil
+}
+
+// LowestLocation reads the almanac from `r` and returns the lowest
+// location number that corresponds to any of its initial seeds.
+func LowestLocation(r io.Reader) (int64, error) {
+	seeds, maps, err := parse(r)
+	if err != nil {
+		return 0, err
+	}
+
+	var seedLocations []int64
+	for _, currSeed := range seeds {
+		currLocation, err := seedLocation(currSeed, maps)
+		if err != nil {
+			return 0, err
+		}
+		seedLocations = append(seedLocations, currLocation)
+	}
+
+	return slices.Min(seedLocations), nil
+}
+
+// LowestRangeLocation reads the almanac from `r`, where the initial seeds
+// are pairs of range start and length, and returns the lowest location
+// number that corresponds to any seed in those ranges.
+func LowestRangeLocation(r io.Reader) (int64, error) {
+	seeds, maps, err := parse(r)
+	if err != nil {
+		return 0, err
+	}
+	if len(seeds)%2 != 0 {
+		return 0, errors.New("seed ranges must be pairs of start and length")
+	}
+
+	var seedRanges []valueRange
+	for i := 0; i < len(seeds); i += 2 {
+		seedRanges = append(seedRanges, valueRange{seeds[i], seeds[i] + seeds[i+1]})
+	}
+
+	locations, err := seedRangeLocations(seedRanges, maps)
+	if err != nil {
+		return 0, err
+	}
+
+	return lo.MinBy(locations, func(a, b valueRange) bool {
+		return a.start < b.start
+	}).start, nil
+}
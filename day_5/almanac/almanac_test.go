@@ -0,0 +1,92 @@
+package almanac
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMap_Find(t *testing.T) {
+	testLookups := []*lookupRange{
+		{
+			destRange:   int64(10),
+			sourceRange: int64(0),
+			rangeLength: int64(10),
+		},
+	}
+	testMap := Map{
+		source: "source",
+		target: "target",
+		lookup: testLookups,
+	}
+
+	inputs := []int64{-1, 0, 1, 9, 10, 15, 20}
+	wants := []int64{-1, 10, 11, 19, 10, 15, 20}
+
+	for i, input := range inputs {
+		actual := testMap.Find(input)
+		if wants[i] != actual {
+			t.Fatalf("[TestMap_Find] for input %d, wanted %d, got %d", input, wants[i], actual)
+		}
+	}
+}
+
+func TestMap_FindRanges(t *testing.T) {
+	testMap := NewMap("source", "target", [][]int64{
+		{50, 98, 2},
+		{52, 50, 48},
+	})
+
+	// The ranges each input range maps to, in any order
+	cases := map[valueRange][]valueRange{
+		{79, 93}:  {{81, 95}},
+		{40, 60}:  {{40, 50}, {52, 62}},
+		{95, 110}: {{97, 100}, {50, 52}, {100, 110}},
+		{0, 1}:    {{0, 1}},
+	}
+	for input, wantRanges := range cases {
+		var wants []int64
+		for _, r := range wantRanges {
+			for k := r.start; k < r.end; k++ {
+				wants = append(wants, k)
+			}
+		}
+
+		var actual []int64
+		for _, r := range testMap.FindRanges([]valueRange{input}) {
+			for k := r.start; k < r.end; k++ {
+				actual = append(actual, k)
+			}
+		}
+
+		slices.Sort(wants)
+		slices.Sort(actual)
+		if !slices.Equal(wants, actual) {
+			t.Fatalf("[TestMap_FindRanges] for range %v, wanted %v, got %v", input, wants, actual)
+		}
+	}
+}
+
+func TestLowestLocation_errors(t *testing.T) {
+	docs := map[string]string{
+		"":                                      "expected a seeds line",
+		"79 14":                                 "expected a seeds line",
+		"seeds:":                                "no seeds given",
+		"seeds: 1 2 x":                          "could not parse seeds: invalid number \"x\"",
+		"seeds: 1\n\nseed-soil map:\n1 2 3":     "invalid map header \"seed-soil map:\"",
+		"seeds: 1\n\nseed-to-soil map:\n1 2":    "seed-to-soil map: expected destination, source and length in \"1 2\"",
+		"seeds: 1\n\nseed-to-soil map:\n1 x 3":  "seed-to-soil map: invalid number \"x\"",
+		"seeds: 1\n\n1 2 3":                     "expected a map header, found \"1 2 3\"",
+		"seeds: 1\n\nsoil-to-water map:\n1 2 3": "map for source not found: seed",
+	}
+
+	for doc, expected := range docs {
+		_, err := LowestLocation(strings.NewReader(doc))
+		if err == nil || err.Error() != expected {
+			t.Fatalf("[TestLowestLocation_errors] for %q expected error %q, actual %v", doc, expected, err)
+		}
+		if _, err := LowestRangeLocation(strings.NewReader(doc)); err == nil {
+			t.Fatalf("[TestLowestLocation_errors] for %q expected an error from LowestRangeLocation", doc)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package almanac
+
+import (
+	"github.com/ubermensch/advent_of_code_2023/solver"
+	"io"
+)
+
+// Solver solves the day 5 puzzle.
+var Solver = &solver.Day{
+	Number: 5,
+	Part1:  part1,
+	Part2:  part2,
+}
+
+func part1(r io.Reader) (solver.Result, error) {
+	lowest, err := LowestLocation(r)
+	return solver.Result{Answer: int(lowest)}, err
+}
+
+func part2(r io.Reader) (solver.Result, error) {
+	lowest, err := LowestRangeLocation(r)
+	return solver.Result{Answer: int(lowest)}, err
+}
@@ -0,0 +1,209 @@
+package boatrace
+
+import (
+	"bufio"
+	"errors"
+	"github.com/samber/lo"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type Strategy struct {
+	// the number of millis to hold the button
+	pressTime int
+	// distance the boat will travel after releasing the button
+	distance int
+}
+
+type Race struct {
+	// the number of millis the race lasts
+	time int
+	// the distance to beat
+	distance int
+	// the possible strategies to finish the race in `time` millis
+	strategies []*Strategy
+}
+
+// WinningStrategies returns the possible strategies of Race `r` that beat the benchmark distance
+// `r.distance`
+func (r *Race) WinningStrategies() []*Strategy {
+	return lo.Filter(r.strategies, func(s *Strategy, _ int) bool {
+		return s.distance > r.distance
+	})
+}
+
+// calculates the possible strategies and writes them to `r.strategies`
+func (r *Race) setStrategies() {
+	var strategies []*Strategy
+
+	for time := 0; time <= r.time; time++ {
+		// when button is let go, boat will move at
+		// 1 millimetre per millisecond of pressTime
+		speed := time
+
+		// will travel distance of (speed * time left)
+		distance := (r.time - time) * speed
+
+		strategies = append(strategies, &Strategy{
+			pressTime: time,
+			distance:  distance,
+		})
+	}
+
+	r.strategies = strategies
+}
+
+func getRaces(times []int, distances []int) ([]*Race, error) {
+	var races []*Race
+
+	if len(times) != len(distances) {
+		return nil, errors.New("mismatched times and distances")
+	}
+
+	// build the races, then concurrently calculate the possible strategies
+	for i := 0; i < len(times); i++ {
+		races = append(races, &Race{
+			time:       times[i],
+			distance:   distances[i],
+			strategies: []*Strategy{},
+		})
+	}
+	var wg sync.WaitGroup
+	wg.Add(len(races))
+	for _, race := range races {
+		go func(r *Race) {
+			r.setStrategies()
+			wg.Done()
+		}(race)
+	}
+	wg.Wait()
+
+	return races, nil
+}
+
+// get the int values from the input lines for time and distance
+func getInts(line string) ([]int, error) {
+	strs := lo.Reject(strings.Split(line, " "), func(s string, i int) bool {
+		return s == ""
+	})
+	var ints []int
+	for _, curr := range strs {
+		currInt, err := strconv.Atoi(curr)
+		if err != nil {
+			return nil, errors.New("could not turn string into int from input: " + curr)
+		}
+		ints = append(ints, currInt)
+	}
+	return ints, nil
+}
+
+// Reads the time and distance lines from `r`, returning the values after
+// their labels.
+func readRecords(r io.Reader) (string, string, error) {
+	scanner := bufio.NewScanner(r)
+
+	var lines []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return "", "", err
+	}
+	if len(lines) < 2 {
+		return "", "", errors.New("expected time and distance lines")
+	}
+
+	_, times, foundTimes := strings.Cut(lines[0], ":")
+	_, distances, foundDistances := strings.Cut(lines[1], ":")
+	if !foundTimes || !foundDistances {
+		return "", "", errors.New("expected time and distance labels")
+	}
+
+	return times, distances, nil
+}
+
+// NewRaces reads the race records from `r` and returns the races with
+// their possible strategies calculated.
+func NewRaces(r io.Reader) ([]*Race, error) {
+	timesStr, distancesStr, err := readRecords(r)
+	if err != nil {
+		return nil, err
+	}
+
+	times, err := getInts(timesStr)
+	if err != nil {
+		return nil, err
+	}
+	distances, err := getInts(distancesStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return getRaces(times, distances)
+}
+
+// NewRace reads the race records from `r` as a single race, ignoring the
+// spaces between the digits of its time and distance. Its strategies are
+// not calculated, as there are too many of them; use WinningCount instead.
+func NewRace(r io.Reader) (*Race, error) {
+	timesStr, distancesStr, err := readRecords(r)
+	if err != nil {
+		return nil, err
+	}
+
+	time, err := getInts(strings.ReplaceAll(timesStr, " ", ""))
+	if err != nil {
+		return nil, err
+	}
+	distance, err := getInts(strings.ReplaceAll(distancesStr, " ", ""))
+	if err != nil {
+		return nil, err
+	}
+	if len(time) != 1 || len(distance) != 1 {
+		return nil, errors.New("expected a single time and distance")
+	}
+
+	return &Race{time: time[0], distance: distance[0]}, nil
+}
+
+// WinningCount returns the number of ways to beat the distance of Race
+// `r`, without enumerating its strategies. Holding the button for `t`
+// millis travels t * (r.time - t), which is symmetric around r.time / 2, so
+// only the shortest winning press time needs to be found.
+func (r *Race) WinningCount() int {
+	wins := func(t int) bool {
+		return t*(r.time-t) > r.distance
+	}
+
+	// the longest distance is travelled at the half-way press time
+	if !wins(r.time / 2) {
+		return 0
+	}
+
+	// estimate from the lower root of t^2 - r.time*t + r.distance = 0...
+	root := math.Sqrt(float64(r.time*r.time - 4*r.distance))
+	shortest := max(0, int((float64(r.time)-root)/2))
+
+	// ...then correct for any floating point error around it
+	for shortest > 0 && wins(shortest-1) {
+		shortest--
+	}
+	for !wins(shortest) {
+		shortest++
+	}
+
+	return r.time - 2*shortest + 1
+}
+
+// Returns the product of the number of winning strategies of each race
+func WinningProduct(races []*Race) int {
+	product := 1
+	for _, race := range races {
+		product *= len(race.WinningStrategies())
+	}
+	return product
+}
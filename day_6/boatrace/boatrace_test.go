@@ -0,0 +1,38 @@
+package boatrace
+
+import (
+	"bytes"
+	"os"
+	"sync"
+	"testing"
+)
+
+func TestNewRaces_concurrent(t *testing.T) {
+	example, err := os.ReadFile("testdata/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// no state is shared between calls, so concurrent calls agree
+	var wg sync.WaitGroup
+	products := make([]int, 8)
+	errs := make([]error, 8)
+	for i := range products {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			races, err := NewRaces(bytes.NewReader(example))
+			products[i], errs[i] = WinningProduct(races), err
+		}()
+	}
+	wg.Wait()
+
+	for i, product := range products {
+		if errs[i] != nil {
+			t.Fatalf("[TestNewRaces_concurrent] unexpected error '%s'", errs[i].Error())
+		}
+		if product != 288 {
+			t.Fatalf("[TestNewRaces_concurrent] expected 288, actual %d", product)
+		}
+	}
+}
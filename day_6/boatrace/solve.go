@@ -0,0 +1,36 @@
+package boatrace
+
+import (
+	"github.com/ubermensch/advent_of_code_2023/solver"
+	"io"
+)
+
+// Solver solves the day 6 puzzle.
+var Solver = &solver.Day{
+	Number: 6,
+	Part1:  part1,
+	Part2:  part2,
+}
+
+func part1(r io.Reader) (solver.Result, error) {
+	races, err := NewRaces(r)
+	if err != nil {
+		return solver.Result{}, err
+	}
+	var winning []int
+	for _, race := range races {
+		winning = append(winning, len(race.WinningStrategies()))
+	}
+	return solver.Result{
+		Answer: WinningProduct(races),
+		Stats:  solver.Stats{"winning_strategies": winning},
+	}, nil
+}
+
+func part2(r io.Reader) (solver.Result, error) {
+	race, err := NewRace(r)
+	if err != nil {
+		return solver.Result{}, err
+	}
+	return solver.Result{Answer: race.WinningCount()}, nil
+}
@@ -46,9 +46,41 @@ const (
 var handTypeStrength = []HandType{fiveOfAKind, fourOfAKind, fullHouse, threeOfAKind, twoPair, onePair, highCard}
 var cardStrength = []CardValue{A, K, Q, J, T, Nine, Eight, Seven, Six, Five, Four, Three, Two}
 
+// When jokers are wild, J cards are the weakest individual cards
+var jokerCardStrength = []CardValue{A, K, Q, T, Nine, Eight, Seven, Six, Five, Four, Three, Two, J}
+
 type Hand struct {
-	Cards    []*Card
-	handType HandType
+	Cards []*Card
+	// Whether J cards are jokers, which act as whichever card makes the
+	// strongest hand type. Fixed when the hand is made, so the cached
+	// hand type can't go stale.
+	jokersWild bool
+	handType   HandType
+}
+
+// Replaces the jokers in `values` with the most common other card value,
+// which always gives the strongest hand type.
+func withJokersReplaced(values []CardValue) []CardValue {
+	counts := make(map[CardValue]int)
+	for _, v := range values {
+		if v != J {
+			counts[v] += 1
+		}
+	}
+	// a hand of only jokers is five of a kind whatever they become
+	if len(counts) == 0 {
+		return values
+	}
+
+	best := lo.MaxBy(maps.Keys(counts), func(a, b CardValue) bool {
+		return counts[a] > counts[b]
+	})
+	return lo.Map(values, func(v CardValue, _ int) CardValue {
+		if v == J {
+			return best
+		}
+		return v
+	})
 }
 
 func handType(h *Hand) HandType {
@@ -84,6 +116,9 @@ func handType(h *Hand) HandType {
 	values := lo.Map(h.Cards, func(c *Card, _ int) CardValue {
 		return c.value
 	})
+	if h.jokersWild {
+		values = withJokersReplaced(values)
+	}
 	handType := calcHandType(values)
 
 	return handType
@@ -101,6 +136,9 @@ func (h *Hand) Type() HandType {
 }
 
 func (h *Hand) IsStrongerThan(other *Hand) (bool, error) {
+	if h.jokersWild != other.jokersWild {
+		return false, errors.New("hands use different joker rules")
+	}
 	var hType, otherType = h.Type(), other.Type()
 	if hType == "" || otherType == "" {
 		return false, errors.New("one or both hand types not valid")
@@ -114,15 +152,19 @@ func (h *Hand) IsStrongerThan(other *Hand) (bool, error) {
 	}
 
 	// Secondary ordering - find first stronger card in sequence of both hands
+	strength := cardStrength
+	if h.jokersWild {
+		strength = jokerCardStrength
+	}
 	hIsStronger := false
 	for i, _ := range h.Cards {
 		hCurr, otherCurr := h.Cards[i].value, other.Cards[i].value
 		// If cards are the same strength, go to next card
-		if slices.Index(cardStrength, hCurr) == slices.Index(cardStrength, otherCurr) {
+		if slices.Index(strength, hCurr) == slices.Index(strength, otherCurr) {
 			continue
 		}
 
-		if slices.Index(cardStrength, hCurr) < slices.Index(cardStrength, otherCurr) {
+		if slices.Index(strength, hCurr) < slices.Index(strength, otherCurr) {
 			hIsStronger = true
 			break
 		} else {
@@ -139,7 +181,9 @@ type Bid struct {
 	BidAmount int
 }
 
-func NewBid(cardStr, bidAmount string) (*Bid, error) {
+// NewBid makes a bid for the hand `cardStr`. The hand follows the joker
+// rules if `jokersWild` is set.
+func NewBid(cardStr, bidAmount string, jokersWild bool) (*Bid, error) {
 	bidInt, err := strconv.Atoi(bidAmount)
 	if err != nil {
 		return nil, errors.New("invalid bidAmount string")
@@ -150,7 +194,7 @@ func NewBid(cardStr, bidAmount string) (*Bid, error) {
 		}
 	})
 
-	return &Bid{Hand: &Hand{Cards: cards}, BidAmount: bidInt}, nil
+	return &Bid{Hand: &Hand{Cards: cards, jokersWild: jokersWild}, BidAmount: bidInt}, nil
 }
 
 func SortByStrength(bids []*Bid) []*Bid {
@@ -82,3 +82,54 @@ func Test_SortByStrength(t *testing.T) {
 		}
 	}
 }
+
+func TestHand_Type_jokersWild(t *testing.T) {
+	hands := map[string]HandType{
+		"32T3K": onePair,
+		"KK677": twoPair,
+		"T55J5": fourOfAKind,
+		"KTJJT": fourOfAKind,
+		"QQQJA": fourOfAKind,
+		"JJJJJ": fiveOfAKind,
+		"J2345": onePair,
+		"JJ234": threeOfAKind,
+		"2J2J3": fourOfAKind,
+		"22J33": fullHouse,
+	}
+
+	for cards, expected := range hands {
+		bid, err := NewBid(cards, "1", true)
+		if err != nil {
+			t.Fatalf("[TestHand_Type_jokersWild] unexpected error '%s'", err.Error())
+		}
+		if bid.Hand.Type() != expected {
+			t.Fatalf("[TestHand_Type_jokersWild] %s expected: %s, actual: %s", cards, expected, bid.Hand.Type())
+		}
+	}
+}
+
+func TestHand_IsStrongerThan_jokersWild(t *testing.T) {
+	// Same hand type: the joker is weaker than any other card
+	weaker, _ := NewBid("JKKK2", "1", true)
+	stronger, _ := NewBid("QQQQ2", "1", true)
+
+	isStronger, err := stronger.Hand.IsStrongerThan(weaker.Hand)
+	if err != nil {
+		t.Fatalf("[TestHand_IsStrongerThan_jokersWild] unexpected error '%s'", err.Error())
+	}
+	if !isStronger {
+		t.Fatalf("[TestHand_IsStrongerThan_jokersWild] expected QQQQ2 to be stronger than JKKK2")
+	}
+}
+
+func TestHand_IsStrongerThan_mixedRules(t *testing.T) {
+	jokers, _ := NewBid("JKKK2", "1", true)
+	plain, _ := NewBid("QQQQ2", "1", false)
+
+	if _, err := jokers.Hand.IsStrongerThan(plain.Hand); err == nil {
+		t.Fatalf("[TestHand_IsStrongerThan_mixedRules] expected an error comparing hands with different joker rules")
+	}
+	if _, err := plain.Hand.IsStrongerThan(jokers.Hand); err == nil {
+		t.Fatalf("[TestHand_IsStrongerThan_mixedRules] expected an error comparing hands with different joker rules")
+	}
+}
@@ -0,0 +1,74 @@
+package camelcards
+
+import (
+	"bufio"
+	"errors"
+	"github.com/samber/lo"
+	"github.com/ubermensch/advent_of_code_2023/solver"
+	"io"
+	"strings"
+)
+
+// NewBids reads the hands and their bid amounts from `r`, one per line.
+// The hands follow the joker rules if `jokersWild` is set.
+func NewBids(r io.Reader, jokersWild bool) ([]*Bid, error) {
+	scanner := bufio.NewScanner(r)
+
+	var bids []*Bid
+	for scanner.Scan() {
+		line := scanner.Text()
+		pieces := strings.Split(line, " ")
+		if len(pieces) != 2 {
+			return nil, errors.New("could not parse bid line: " + line)
+		}
+		cards, bidAmount := pieces[0], pieces[1]
+		newBid, err := NewBid(cards, bidAmount, jokersWild)
+		if err != nil {
+			return nil, errors.New("could not create new bid for: " + cards + " " + bidAmount)
+		}
+		bids = append(bids, newBid)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return bids, nil
+}
+
+// TotalWinnings ranks the bids by hand strength and returns the sum of
+// each bid amount multiplied by its rank.
+func TotalWinnings(bids []*Bid) int {
+	bids = SortByStrength(bids)
+	return lo.Reduce(bids, func(total int, bid *Bid, i int) int {
+		return total + (bid.BidAmount * (i + 1))
+	}, 0)
+}
+
+// Solver solves the day 7 puzzle.
+var Solver = &solver.Day{
+	Number: 7,
+	Part1:  part1,
+	Part2:  part2,
+}
+
+func part1(r io.Reader) (solver.Result, error) {
+	bids, err := NewBids(r, false)
+	if err != nil {
+		return solver.Result{}, err
+	}
+	return solver.Result{
+		Answer: TotalWinnings(bids),
+		Stats:  solver.Stats{"hands": len(bids)},
+	}, nil
+}
+
+func part2(r io.Reader) (solver.Result, error) {
+	bids, err := NewBids(r, true)
+	if err != nil {
+		return solver.Result{}, err
+	}
+	return solver.Result{
+		Answer: TotalWinnings(bids),
+		Stats:  solver.Stats{"hands": len(bids)},
+	}, nil
+}
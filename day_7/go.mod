@@ -1,8 +0,0 @@
-module day_7
-
-go 1.21.7
-
-require (
-	github.com/samber/lo v1.39.0
-	golang.org/x/exp v0.0.0-20220303212507-bbda1eaf7a17
-)
@@ -1,52 +1,34 @@
 package main
 
 import (
-	"bufio"
-	"day_7/camelcards"
+	"flag"
 	"fmt"
-	"github.com/samber/lo"
-	"os"
-	"path"
-	"strings"
+	"github.com/ubermensch/advent_of_code_2023/day_7/camelcards"
+	"github.com/ubermensch/advent_of_code_2023/input"
+	"github.com/ubermensch/advent_of_code_2023/solver"
+	"log"
 )
 
-const inputFile = "/Users/frankhmeidan/golang/advent_of_code/day_7/input.txt"
+var (
+	inputPath = flag.String("input", "", "path to the puzzle input, or - to read stdin")
+	part      solver.Part
+)
 
-func fileScanner() (*bufio.Scanner, *os.File) {
-	filePath := path.Join(inputFile)
-	file, err := os.Open(filePath)
-	if err != nil {
-		panic("could not open file")
-	}
+func main() {
+	flag.Var(&part, "part", "part to solve: 1, 2 or both")
+	flag.Parse()
 
-	scanner := bufio.NewScanner(file)
+	file, err := input.Open(7, *inputPath)
 	if err != nil {
-		panic("could not scan file")
+		log.Fatal(err)
 	}
-
-	return scanner, file
-}
-
-func main() {
-	scanner, file := fileScanner()
 	defer file.Close()
 
-	var bids []*camelcards.Bid
-	for scanner.Scan() {
-		line := scanner.Text()
-		pieces := strings.Split(line, " ")
-		cards, bidAmount := pieces[0], pieces[1]
-		newBid, err := camelcards.NewBid(cards, bidAmount)
-		if err != nil {
-			panic("could not create new bid for: " + cards + " " + bidAmount)
-		}
-		bids = append(bids, newBid)
+	results, err := camelcards.Solver.Solve(file, part)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, result := range results {
+		fmt.Println(result)
 	}
-
-	bids = camelcards.SortByStrength(bids)
-	totalWinnings := lo.Reduce(bids, func(total int, bid *camelcards.Bid, i int) int {
-		return total + (bid.BidAmount * (i + 1))
-	}, 0)
-
-	fmt.Println("Total winnings: ", totalWinnings)
 }
@@ -1,39 +1,34 @@
 package main
 
 import (
-	"bufio"
-	"day_8/network"
+	"flag"
 	"fmt"
-	"os"
-	"path"
+	"github.com/ubermensch/advent_of_code_2023/day_8/network"
+	"github.com/ubermensch/advent_of_code_2023/input"
+	"github.com/ubermensch/advent_of_code_2023/solver"
+	"log"
 )
 
-const inputFile = "/Users/frankhmeidan/golang/advent_of_code/day_8/input.txt"
+var (
+	inputPath = flag.String("input", "", "path to the puzzle input, or - to read stdin")
+	part      solver.Part
+)
 
-func fileScanner() (*bufio.Scanner, *os.File) {
-	filePath := path.Join(inputFile)
-	file, err := os.Open(filePath)
-	if err != nil {
-		panic("could not open file")
-	}
+func main() {
+	flag.Var(&part, "part", "part to solve: 1, 2 or both")
+	flag.Parse()
 
-	scanner := bufio.NewScanner(file)
+	file, err := input.Open(8, *inputPath)
 	if err != nil {
-		panic("could not scan file")
+		log.Fatal(err)
 	}
-
-	return scanner, file
-}
-
-func main() {
-	scanner, file := fileScanner()
 	defer file.Close()
 
-	maze, err := network.NewNetwork(scanner)
+	results, err := network.Solver.Solve(file, part)
 	if err != nil {
-		panic("could not build network")
+		log.Fatal(err)
+	}
+	for _, result := range results {
+		fmt.Println(result)
 	}
-	steps := maze.StepsToFinish()
-	bothSteps := maze.LcmStepsToFinish()
-	fmt.Printf("steps to finish: %d, steps to finish in parallel: %d\n", steps, bothSteps)
 }
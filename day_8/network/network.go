@@ -2,6 +2,8 @@ package network
 
 import (
 	"bufio"
+	"errors"
+	"fmt"
 	"github.com/samber/lo"
 	"strings"
 )
@@ -46,30 +48,46 @@ type Network struct {
 	lookup     map[string]*Node
 }
 
+// NewNetwork reads the network from `s`: a line of L and R directions, a
+// blank line, then one node per line, such as "AAA = (BBB, CCC)".
 func NewNetwork(s *bufio.Scanner) (*Network, error) {
-	getDirections := func(dirStr string) []rune {
-		return []rune(dirStr)
-	}
-	getNodes := func(lines []string) []*Node {
-		return lo.Map(lines, func(s string, i int) *Node {
-			pieces := strings.Split(s, "=")
-			label := strings.Trim(pieces[0], " ")
-			next := strings.Trim(pieces[1], " (4)")
-			leftAndRight := strings.Split(next, ",")
-			left, right := strings.Trim(leftAndRight[0], " "), strings.Trim(leftAndRight[1], " ")
-			return &Node{
-				label: label,
-				left:  left,
-				right: right,
+	getDirections := func(dirStr string) ([]rune, error) {
+		if dirStr == "" {
+			return nil, errors.New("expected a line of directions")
+		}
+		for _, dir := range dirStr {
+			if dir != 'L' && dir != 'R' {
+				return nil, fmt.Errorf("invalid direction %q", dir)
 			}
-		})
+		}
+		return []rune(dirStr), nil
+	}
+	getNode := func(line string) (*Node, error) {
+		label, next, ok := strings.Cut(line, "=")
+		left, right, okComma := strings.Cut(strings.Trim(strings.TrimSpace(next), "()"), ",")
+		node := &Node{
+			label: strings.TrimSpace(label),
+			left:  strings.TrimSpace(left),
+			right: strings.TrimSpace(right),
+		}
+		if !ok || !okComma || node.label == "" || node.left == "" || node.right == "" {
+			return nil, fmt.Errorf("invalid node %q", line)
+		}
+		return node, nil
 	}
-	getLookup := func(nodes []*Node) map[string]*Node {
+	getLookup := func(nodes []*Node) (map[string]*Node, error) {
 		lookup := make(map[string]*Node)
 		for _, node := range nodes {
 			lookup[node.label] = node
 		}
-		return lookup
+		for _, node := range nodes {
+			for _, next := range []string{node.left, node.right} {
+				if _, ok := lookup[next]; !ok {
+					return nil, fmt.Errorf("node %s leads to unknown node %s", node.label, next)
+				}
+			}
+		}
+		return lookup, nil
 	}
 
 	var lines []string
@@ -77,9 +95,32 @@ func NewNetwork(s *bufio.Scanner) (*Network, error) {
 		line := s.Text()
 		lines = append(lines, line)
 	}
-	directions := getDirections(lines[0])
-	nodes := getNodes(lines[2:])
-	lookup := getLookup(nodes)
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, errors.New("expected a line of directions")
+	}
+
+	directions, err := getDirections(lines[0])
+	if err != nil {
+		return nil, err
+	}
+	var nodes []*Node
+	for _, line := range lines[1:] {
+		if line == "" {
+			continue
+		}
+		node, err := getNode(line)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	lookup, err := getLookup(nodes)
+	if err != nil {
+		return nil, err
+	}
 
 	return &Network{
 		directions: directions,
@@ -89,21 +130,25 @@ func NewNetwork(s *bufio.Scanner) (*Network, error) {
 
 }
 
-func (n *Network) StepsToFinish() int {
+// Returns the node reached from `curr` by going in `direction`.
+func (n *Network) next(curr *Node, direction rune) *Node {
+	if direction == 'L' {
+		return n.lookup[curr.left]
+	}
+	return n.lookup[curr.right]
+}
+
+func (n *Network) StepsToFinish() (int, error) {
+	currNode, ok := n.lookup[Start]
+	if !ok {
+		return 0, errors.New("no node " + Start + " to start from")
+	}
 	steps := 0
-	currNode := n.lookup[Start]
 	exitFound := false
 
 	for !exitFound {
 		for _, curr := range n.directions {
-			switch curr {
-			case 'L':
-				currNode = n.lookup[currNode.left]
-			case 'R':
-				currNode = n.lookup[currNode.right]
-			default:
-				panic("direction not left or right")
-			}
+			currNode = n.next(currNode, curr)
 			steps += 1
 
 			if currNode.label == End {
@@ -112,23 +157,15 @@ func (n *Network) StepsToFinish() int {
 			}
 		}
 	}
-	return steps
+	return steps, nil
 }
 
-func (n *Network) LcmStepsToFinish() int {
+func (n *Network) LcmStepsToFinish() (int, error) {
 	startingNodes := lo.Filter(n.nodes, func(n *Node, i int) bool {
-		return []byte(n.label)[2] == 'A'
+		return n.EndsWith('A')
 	})
-
-	nextNode := func(curr *Node, direction rune) *Node {
-		switch direction {
-		case 'L':
-			return n.lookup[curr.left]
-		case 'R':
-			return n.lookup[curr.right]
-		default:
-			panic("direction not left or right")
-		}
+	if len(startingNodes) == 0 {
+		return 0, errors.New("no nodes ending in A to start from")
 	}
 
 	stepsToFinish := func(node *Node) int {
@@ -139,7 +176,7 @@ func (n *Network) LcmStepsToFinish() int {
 		for {
 			dir = n.directions[dirI]
 			steps += 1
-			currNode = nextNode(currNode, dir)
+			currNode = n.next(currNode, dir)
 			if currNode.EndsWith('Z') {
 				break
 			}
@@ -158,5 +195,7 @@ func (n *Network) LcmStepsToFinish() int {
 		steps = append(steps, stepsToFinish(curr))
 	}
 
-	return lcm(steps[0], steps[1], steps[2:]...)
+	return lo.Reduce(steps[1:], func(result int, s int, _ int) int {
+		return lcm(result, s)
+	}, steps[0]), nil
 }
@@ -0,0 +1,39 @@
+package network
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func TestNewNetwork_errors(t *testing.T) {
+	docs := map[string]string{
+		"":                                   "expected a line of directions",
+		"\n\nAAA = (AAA, AAA)":               "expected a line of directions",
+		"LRX\n\nAAA = (AAA, AAA)":            "invalid direction 'X'",
+		"LR\n\nAAA (AAA, AAA)":               "invalid node \"AAA (AAA, AAA)\"",
+		"LR\n\nAAA = (AAA)":                  "invalid node \"AAA = (AAA)\"",
+		"LR\n\nAAA = (BBB, AAA)":             "node AAA leads to unknown node BBB",
+		"LR\n\nAAA = (AAA, BBB)\nBBB = (, )": "invalid node \"BBB = (, )\"",
+	}
+
+	for doc, expected := range docs {
+		_, err := NewNetwork(bufio.NewScanner(strings.NewReader(doc)))
+		if err == nil || err.Error() != expected {
+			t.Fatalf("[TestNewNetwork_errors] for %q expected error %q, actual %v", doc, expected, err)
+		}
+	}
+}
+
+func TestNetwork_StepsToFinish_errors(t *testing.T) {
+	network, err := NewNetwork(bufio.NewScanner(strings.NewReader("L\n\nBBB = (ZZZ, ZZZ)\nZZZ = (ZZZ, ZZZ)")))
+	if err != nil {
+		t.Fatalf("[TestNetwork_StepsToFinish_errors] unexpected error '%s'", err.Error())
+	}
+	if _, err := network.StepsToFinish(); err == nil {
+		t.Fatalf("[TestNetwork_StepsToFinish_errors] expected an error without an AAA node")
+	}
+	if _, err := network.LcmStepsToFinish(); err == nil {
+		t.Fatalf("[TestNetwork_StepsToFinish_errors] expected an error without a node ending in A")
+	}
+}
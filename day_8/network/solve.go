@@ -0,0 +1,32 @@
+package network
+
+import (
+	"bufio"
+	"github.com/ubermensch/advent_of_code_2023/solver"
+	"io"
+)
+
+// Solver solves the day 8 puzzle.
+var Solver = &solver.Day{
+	Number: 8,
+	Part1:  part1,
+	Part2:  part2,
+}
+
+func part1(r io.Reader) (solver.Result, error) {
+	maze, err := NewNetwork(bufio.NewScanner(r))
+	if err != nil {
+		return solver.Result{}, err
+	}
+	steps, err := maze.StepsToFinish()
+	return solver.Result{Answer: steps}, err
+}
+
+func part2(r io.Reader) (solver.Result, error) {
+	maze, err := NewNetwork(bufio.NewScanner(r))
+	if err != nil {
+		return solver.Result{}, err
+	}
+	steps, err := maze.LcmStepsToFinish()
+	return solver.Result{Answer: steps}, err
+}
@@ -0,0 +1,15 @@
+package network
+
+import (
+	"github.com/ubermensch/advent_of_code_2023/solver"
+	"github.com/ubermensch/advent_of_code_2023/solver/solvertest"
+	"testing"
+)
+
+func TestSolver_examples(t *testing.T) {
+	solvertest.Run(t, Solver, []solvertest.Example{
+		{File: "example.txt", Part: solver.One, Want: 2},
+		{File: "example_repeat.txt", Part: solver.One, Want: 6},
+		{File: "example_ghosts.txt", Part: solver.Two, Want: 6},
+	})
+}
@@ -1,8 +0,0 @@
-module day_9
-
-go 1.22.1
-
-require (
-	github.com/samber/lo v1.44.0 // indirect
-	golang.org/x/text v0.16.0 // indirect
-)
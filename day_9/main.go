@@ -1,65 +1,34 @@
 package main
 
 import (
-	"bufio"
-	"day_9/predictor"
+	"flag"
 	"fmt"
-	"github.com/samber/lo"
-	"os"
-	"path"
-	"strconv"
-	"strings"
+	"github.com/ubermensch/advent_of_code_2023/day_9/predictor"
+	"github.com/ubermensch/advent_of_code_2023/input"
+	"github.com/ubermensch/advent_of_code_2023/solver"
+	"log"
 )
 
-const inputFile = "/Users/frankhmeidan/golang/advent_of_code/day_9/input.txt"
+var (
+	inputPath = flag.String("input", "", "path to the puzzle input, or - to read stdin")
+	part      solver.Part
+)
 
-func fileScanner() (*bufio.Scanner, *os.File) {
-	filePath := path.Join(inputFile)
-	file, err := os.Open(filePath)
-	if err != nil {
-		panic("could not open file")
-	}
+func main() {
+	flag.Var(&part, "part", "part to solve: 1, 2 or both")
+	flag.Parse()
 
-	scanner := bufio.NewScanner(file)
+	file, err := input.Open(9, *inputPath)
 	if err != nil {
-		panic("could not scan file")
+		log.Fatal(err)
 	}
-
-	return scanner, file
-}
-
-func main() {
-	scanner, file := fileScanner()
 	defer file.Close()
 
-	var allSeries []*predictor.Series
-	var histories [][]int
-	for scanner.Scan() {
-		line := scanner.Text()
-		history := lo.Map(strings.Split(line, " "), func(s string, idx int) int {
-			i, err := strconv.Atoi(s)
-			if err != nil {
-				panic("could not parse line")
-			}
-			return i
-		})
-		histories = append(histories, history)
+	results, err := predictor.Solver.Solve(file, part)
+	if err != nil {
+		log.Fatal(err)
 	}
-
-	for _, curr := range histories {
-		allSeries = append(allSeries, predictor.NewSeries(curr))
+	for _, result := range results {
+		fmt.Println(result)
 	}
-
-	totalNext := lo.Reduce(allSeries, func(total int, s *predictor.Series, idx int) int {
-		next := s.Next()
-		return total + next
-	}, 0)
-
-	totalPrev := lo.Reduce(allSeries, func(total int, s *predictor.Series, idx int) int {
-		previous := s.Previous()
-		return total + previous
-	}, 0)
-
-	fmt.Println("Total of all next predictions: " + strconv.Itoa(totalNext))
-	fmt.Println("Total of all prev predictions: " + strconv.Itoa(totalPrev))
 }
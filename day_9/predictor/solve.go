@@ -0,0 +1,80 @@
+package predictor
+
+import (
+	"bufio"
+	"errors"
+	"github.com/samber/lo"
+	"github.com/ubermensch/advent_of_code_2023/solver"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// NewSeriesList reads the value histories from `r`, one per line, and
+// returns a Series for each of them.
+func NewSeriesList(r io.Reader) ([]*Series, error) {
+	scanner := bufio.NewScanner(r)
+
+	var allSeries []*Series
+	for scanner.Scan() {
+		line := scanner.Text()
+		var history []int
+		for _, s := range strings.Split(line, " ") {
+			i, err := strconv.Atoi(s)
+			if err != nil {
+				return nil, errors.New("could not parse line: " + line)
+			}
+			history = append(history, i)
+		}
+		allSeries = append(allSeries, NewSeries(history))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return allSeries, nil
+}
+
+// TotalNext returns the sum of the next predicted value of every series.
+func TotalNext(allSeries []*Series) int {
+	return lo.Reduce(allSeries, func(total int, s *Series, idx int) int {
+		return total + s.Next()
+	}, 0)
+}
+
+// TotalPrevious returns the sum of the previous predicted value of every
+// series.
+func TotalPrevious(allSeries []*Series) int {
+	return lo.Reduce(allSeries, func(total int, s *Series, idx int) int {
+		return total + s.Previous()
+	}, 0)
+}
+
+// Solver solves the day 9 puzzle.
+var Solver = &solver.Day{
+	Number: 9,
+	Part1:  part1,
+	Part2:  part2,
+}
+
+func part1(r io.Reader) (solver.Result, error) {
+	allSeries, err := NewSeriesList(r)
+	if err != nil {
+		return solver.Result{}, err
+	}
+	return solver.Result{
+		Answer: TotalNext(allSeries),
+		Stats:  solver.Stats{"series": len(allSeries)},
+	}, nil
+}
+
+func part2(r io.Reader) (solver.Result, error) {
+	allSeries, err := NewSeriesList(r)
+	if err != nil {
+		return solver.Result{}, err
+	}
+	return solver.Result{
+		Answer: TotalPrevious(allSeries),
+		Stats:  solver.Stats{"series": len(allSeries)},
+	}, nil
+}
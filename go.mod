@@ -0,0 +1,12 @@
+module github.com/ubermensch/advent_of_code_2023
+
+go 1.22.1
+
+require (
+	github.com/BurntSushi/toml v1.4.0
+	github.com/samber/lo v1.44.0
+	github.com/thoas/go-funk v0.9.3
+	golang.org/x/exp v0.0.0-20220303212507-bbda1eaf7a17
+)
+
+require golang.org/x/text v0.16.0 // indirect
@@ -0,0 +1,248 @@
+// Package grid holds two-dimensional grids of cells, as found in many
+// puzzle inputs.
+package grid
+
+import (
+	"bufio"
+	"io"
+)
+
+// Pos is a position in a grid. X is the column and Y the row, both
+// 0-indexed from the top left.
+type Pos struct {
+	X int
+	Y int
+}
+
+func (p Pos) Add(other Pos) Pos {
+	return Pos{X: p.X + other.X, Y: p.Y + other.Y}
+}
+
+// The offsets of the 4 orthogonal neighbours of a position.
+var Orthogonal = []Pos{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
+
+// The offsets of the 8 neighbours of a position, diagonals included.
+var Surrounding = []Pos{{-1, -1}, {0, -1}, {1, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}}
+
+// Grid is a grid of cells of type T. Rows may have different lengths.
+type Grid[T any] struct {
+	rows [][]T
+}
+
+// New returns a grid of the given rows, which it takes ownership of.
+func New[T any](rows [][]T) *Grid[T] {
+	return &Grid[T]{rows: rows}
+}
+
+// Parse reads a grid from `r`, one row per line, turning each rune of a
+// line into a cell with `cell`. Trailing carriage returns are dropped.
+func Parse[T any](r io.Reader, cell func(p Pos, value rune) (T, error)) (*Grid[T], error) {
+	scanner := bufio.NewScanner(r)
+
+	var rows [][]T
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(line) > 0 && line[len(line)-1] == '\r' {
+			line = line[:len(line)-1]
+		}
+
+		row := []T{}
+		for _, value := range line {
+			c, err := cell(Pos{X: len(row), Y: len(rows)}, value)
+			if err != nil {
+				return nil, err
+			}
+			row = append(row, c)
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return New(rows), nil
+}
+
+// ParseRunes reads a grid of runes from `r`, one row per line.
+func ParseRunes(r io.Reader) (*Grid[rune], error) {
+	return Parse(r, func(_ Pos, value rune) (rune, error) { return value, nil })
+}
+
+// Height returns the number of rows.
+func (g *Grid[T]) Height() int {
+	return len(g.rows)
+}
+
+// Width returns the length of row `y`, or 0 if there is no such row.
+func (g *Grid[T]) Width(y int) int {
+	if y < 0 || y >= len(g.rows) {
+		return 0
+	}
+	return len(g.rows[y])
+}
+
+// InBounds returns whether there is a cell at `p`.
+func (g *Grid[T]) InBounds(p Pos) bool {
+	return p.Y >= 0 && p.Y < len(g.rows) && p.X >= 0 && p.X < len(g.rows[p.Y])
+}
+
+// Get returns the cell at `p`, and whether there is one.
+func (g *Grid[T]) Get(p Pos) (T, bool) {
+	if !g.InBounds(p) {
+		var zero T
+		return zero, false
+	}
+	return g.rows[p.Y][p.X], true
+}
+
+// Set sets the cell at `p`, returning false if there is no such cell.
+func (g *Grid[T]) Set(p Pos, value T) bool {
+	if !g.InBounds(p) {
+		return false
+	}
+	g.rows[p.Y][p.X] = value
+	return true
+}
+
+// Row returns the cells of row `y`, or nil if there is no such row. The
+// row is shared with the grid.
+func (g *Grid[T]) Row(y int) []T {
+	if y < 0 || y >= len(g.rows) {
+		return nil
+	}
+	return g.rows[y]
+}
+
+// Column returns the cells of column `x`, from the top down, skipping
+// rows too short to have one.
+func (g *Grid[T]) Column(x int) []T {
+	var column []T
+	for y := range g.rows {
+		if value, ok := g.Get(Pos{X: x, Y: y}); ok {
+			column = append(column, value)
+		}
+	}
+	return column
+}
+
+// Each calls `fn` with every cell of the grid, row by row.
+func (g *Grid[T]) Each(fn func(p Pos, value T)) {
+	for y, row := range g.rows {
+		for x, value := range row {
+			fn(Pos{X: x, Y: y}, value)
+		}
+	}
+}
+
+// Returns the positions at the given offsets from `p` that are in the grid.
+func (g *Grid[T]) around(p Pos, offsets []Pos) []Pos {
+	var neighbours []Pos
+	for _, offset := range offsets {
+		if n := p.Add(offset); g.InBounds(n) {
+			neighbours = append(neighbours, n)
+		}
+	}
+	return neighbours
+}
+
+// Neighbours4 returns the orthogonal neighbours of `p` that are in the grid.
+func (g *Grid[T]) Neighbours4(p Pos) []Pos {
+	return g.around(p, Orthogonal)
+}
+
+// Neighbours8 returns the neighbours of `p`, diagonals included, that are
+// in the grid.
+func (g *Grid[T]) Neighbours8(p Pos) []Pos {
+	return g.around(p, Surrounding)
+}
+
+// The offsets from one cell of a run to the next, along a row and down a
+// column.
+var (
+	Across = Pos{X: 1, Y: 0}
+	Down   = Pos{X: 0, Y: 1}
+)
+
+// A Run is a sequence of consecutive cells in a row or column.
+type Run struct {
+	Start Pos
+	Len   int
+	// The offset from each cell of the run to the next: Across for a run
+	// in a row, Down for one in a column.
+	Step Pos
+}
+
+// Positions returns the positions of the cells of the run, in order.
+func (r Run) Positions() []Pos {
+	positions := make([]Pos, r.Len)
+	for i := range positions {
+		positions[i] = Pos{X: r.Start.X + i*r.Step.X, Y: r.Start.Y + i*r.Step.Y}
+	}
+	return positions
+}
+
+// RowRuns returns the maximal runs of consecutive cells of row `y` that
+// `match`, from left to right.
+func (g *Grid[T]) RowRuns(y int, match func(T) bool) []Run {
+	var runs []Run
+	row := g.Row(y)
+	for x := 0; x < len(row); x++ {
+		if !match(row[x]) {
+			continue
+		}
+		start := x
+		for x < len(row) && match(row[x]) {
+			x++
+		}
+		runs = append(runs, Run{Start: Pos{X: start, Y: y}, Len: x - start, Step: Across})
+	}
+	return runs
+}
+
+// ColumnRuns returns the maximal runs of consecutive cells of column `x`
+// that `match`, from the top down. A row too short to reach the column
+// ends a run.
+func (g *Grid[T]) ColumnRuns(x int, match func(T) bool) []Run {
+	var runs []Run
+	for y := 0; y < len(g.rows); y++ {
+		if value, ok := g.Get(Pos{X: x, Y: y}); !ok || !match(value) {
+			continue
+		}
+		start := y
+		for ; y < len(g.rows); y++ {
+			if value, ok := g.Get(Pos{X: x, Y: y}); !ok || !match(value) {
+				break
+			}
+		}
+		runs = append(runs, Run{Start: Pos{X: x, Y: start}, Len: y - start, Step: Down})
+	}
+	return runs
+}
+
+// FloodFill returns the region of positions reachable from `start` by
+// moving between cells that `include`, orthogonally or, if `diagonal` is
+// set, diagonally too. The region is in the order found, starting with
+// `start`, and is empty if `start` itself is not included.
+func (g *Grid[T]) FloodFill(start Pos, diagonal bool, include func(p Pos, value T) bool) []Pos {
+	offsets := Orthogonal
+	if diagonal {
+		offsets = Surrounding
+	}
+	if value, ok := g.Get(start); !ok || !include(start, value) {
+		return nil
+	}
+
+	region := []Pos{start}
+	seen := map[Pos]bool{start: true}
+	for i := 0; i < len(region); i++ {
+		for _, n := range g.around(region[i], offsets) {
+			if seen[n] {
+				continue
+			}
+			seen[n] = true
+			if value, _ := g.Get(n); include(n, value) {
+				region = append(region, n)
+			}
+		}
+	}
+	return region
+}
@@ -0,0 +1,145 @@
+package grid
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func mustParse(t *testing.T, doc string) *Grid[rune] {
+	g, err := ParseRunes(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestParse(t *testing.T) {
+	g := mustParse(t, "ab\r\ncde\r\n\r\nf")
+	if g.Height() != 4 {
+		t.Fatalf("[TestParse] expected 4 rows, actual %d", g.Height())
+	}
+	for y, width := range []int{2, 3, 0, 1} {
+		if g.Width(y) != width {
+			t.Fatalf("[TestParse] expected row %d to have width %d, actual %d", y, width, g.Width(y))
+		}
+	}
+	if string(g.Row(1)) != "cde" {
+		t.Fatalf("[TestParse] expected row 1 to be cde, actual %s", string(g.Row(1)))
+	}
+
+	errBad := errors.New("bad cell")
+	_, err := Parse(strings.NewReader("12\n3x"), func(p Pos, value rune) (int, error) {
+		if value < '0' || value > '9' {
+			return 0, errBad
+		}
+		return int(value - '0'), nil
+	})
+	if !errors.Is(err, errBad) {
+		t.Fatalf("[TestParse] expected %v, actual %v", errBad, err)
+	}
+}
+
+func TestGrid_Get(t *testing.T) {
+	g := mustParse(t, "ab\ncde")
+
+	for p, expected := range map[Pos]rune{{0, 0}: 'a', {1, 0}: 'b', {2, 1}: 'e'} {
+		if value, ok := g.Get(p); !ok || value != expected {
+			t.Fatalf("[TestGrid_Get] expected %c at %v, actual %c", expected, p, value)
+		}
+	}
+	for _, p := range []Pos{{-1, 0}, {0, -1}, {2, 0}, {3, 1}, {0, 2}} {
+		if _, ok := g.Get(p); ok {
+			t.Fatalf("[TestGrid_Get] expected no cell at %v", p)
+		}
+		if g.Set(p, 'z') {
+			t.Fatalf("[TestGrid_Get] expected no cell to set at %v", p)
+		}
+	}
+
+	if !g.Set(Pos{2, 1}, 'z') {
+		t.Fatalf("[TestGrid_Get] expected to set the cell at {2 1}")
+	}
+	if value, _ := g.Get(Pos{2, 1}); value != 'z' {
+		t.Fatalf("[TestGrid_Get] expected z at {2 1}, actual %c", value)
+	}
+}
+
+func TestGrid_Neighbours(t *testing.T) {
+	g := mustParse(t, "abc\ndef\nghi")
+
+	if n := g.Neighbours4(Pos{1, 1}); len(n) != 4 {
+		t.Fatalf("[TestGrid_Neighbours] expected 4 neighbours, actual %v", n)
+	}
+	if n := g.Neighbours8(Pos{1, 1}); len(n) != 8 {
+		t.Fatalf("[TestGrid_Neighbours] expected 8 neighbours, actual %v", n)
+	}
+
+	corner := g.Neighbours8(Pos{0, 0})
+	expected := []Pos{{1, 0}, {1, 1}, {0, 1}}
+	if !reflect.DeepEqual(corner, expected) {
+		t.Fatalf("[TestGrid_Neighbours] expected %v, actual %v", expected, corner)
+	}
+	if n := g.Neighbours4(Pos{0, 0}); len(n) != 2 {
+		t.Fatalf("[TestGrid_Neighbours] expected 2 neighbours, actual %v", n)
+	}
+}
+
+func TestGrid_Runs(t *testing.T) {
+	g := mustParse(t, "12..34\n5.6\n7..8\n9")
+	isDigit := func(r rune) bool { return r >= '0' && r <= '9' }
+
+	rows := g.RowRuns(0, isDigit)
+	expected := []Run{{Start: Pos{0, 0}, Len: 2, Step: Across}, {Start: Pos{4, 0}, Len: 2, Step: Across}}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Fatalf("[TestGrid_Runs] expected %v, actual %v", expected, rows)
+	}
+	if positions := rows[1].Positions(); !slices.Equal(positions, []Pos{{4, 0}, {5, 0}}) {
+		t.Fatalf("[TestGrid_Runs] unexpected positions %v", positions)
+	}
+
+	columns := g.ColumnRuns(0, isDigit)
+	expected = []Run{{Start: Pos{0, 0}, Len: 4, Step: Down}}
+	if !reflect.DeepEqual(columns, expected) {
+		t.Fatalf("[TestGrid_Runs] expected %v, actual %v", expected, columns)
+	}
+	if positions := columns[0].Positions(); !slices.Equal(positions, []Pos{{0, 0}, {0, 1}, {0, 2}, {0, 3}}) {
+		t.Fatalf("[TestGrid_Runs] unexpected column positions %v", positions)
+	}
+	// row 3 is too short to reach column 3
+	columns = g.ColumnRuns(3, isDigit)
+	expected = []Run{{Start: Pos{3, 2}, Len: 1, Step: Down}}
+	if !reflect.DeepEqual(columns, expected) {
+		t.Fatalf("[TestGrid_Runs] expected %v, actual %v", expected, columns)
+	}
+	if column := string(g.Column(2)); column != ".6." {
+		t.Fatalf("[TestGrid_Runs] expected column .6., actual %s", column)
+	}
+}
+
+func TestGrid_FloodFill(t *testing.T) {
+	g := mustParse(t, strings.Join([]string{
+		"##..#",
+		"#..#.",
+		"..#..",
+		"#.#..",
+	}, "\n"))
+	isDot := func(_ Pos, value rune) bool { return value == '.' }
+
+	region := g.FloodFill(Pos{2, 0}, false, isDot)
+	if len(region) != 7 || region[0] != (Pos{2, 0}) {
+		t.Fatalf("[TestGrid_FloodFill] expected a region of 7 from {2 0}, actual %v", region)
+	}
+	// diagonally the dots to the right join up
+	if region := g.FloodFill(Pos{2, 0}, true, isDot); len(region) != 12 {
+		t.Fatalf("[TestGrid_FloodFill] expected a region of 12, actual %v", region)
+	}
+	if region := g.FloodFill(Pos{0, 0}, false, isDot); len(region) != 0 {
+		t.Fatalf("[TestGrid_FloodFill] expected an empty region, actual %v", region)
+	}
+	if region := g.FloodFill(Pos{9, 9}, false, isDot); len(region) != 0 {
+		t.Fatalf("[TestGrid_FloodFill] expected an empty region, actual %v", region)
+	}
+}
@@ -0,0 +1,162 @@
+// Package input locates and opens the puzzle input of a day.
+//
+// The input is resolved from, in order:
+//   - an explicit path (typically from an -input flag), where "-" means stdin
+//   - the directory named by the AOC_INPUT_DIR environment variable, which is
+//     expected to hold a day_N/input.txt file per day
+//   - the day's own directory (day_N/input.txt), searched for from the working
+//     directory upwards
+//   - stdin, when it is not a terminal
+package input
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// EnvDir is the environment variable naming the directory holding the
+// puzzle inputs.
+const EnvDir = "AOC_INPUT_DIR"
+
+// FileName is the name of the input file within a day's directory.
+const FileName = "input.txt"
+
+// ErrNotFound is returned when no input could be found for a day.
+var ErrNotFound = errors.New("puzzle input not found")
+
+// Resolver finds the input for a day.
+type Resolver struct {
+	// Explicit path to the input. Takes precedence over everything else;
+	// "-" reads from Stdin.
+	Path string
+	// Directory holding a day_N/input.txt file per day.
+	Dir string
+	// Working directory to search upwards from for the day's directory.
+	// Defaults to the process' working directory.
+	WorkDir string
+	// Read when no input file is found. An *os.File is only used when it is
+	// not a terminal; nil disables the fallback.
+	Stdin io.Reader
+}
+
+// NewResolver returns a Resolver for the given explicit `path` (which may
+// be empty), reading the remaining configuration from the environment.
+func NewResolver(path string) *Resolver {
+	return &Resolver{
+		Path:  path,
+		Dir:   os.Getenv(EnvDir),
+		Stdin: os.Stdin,
+	}
+}
+
+// Open is shorthand for NewResolver(path).Open(day).
+func Open(day int, path string) (io.ReadCloser, error) {
+	return NewResolver(path).Open(day)
+}
+
+// DayDir returns the name of the directory holding the given day.
+func DayDir(day int) string {
+	return fmt.Sprintf("day_%d", day)
+}
+
+// Open returns a reader for the input of the given day. The caller is
+// responsible for closing it.
+func (r *Resolver) Open(day int) (io.ReadCloser, error) {
+	if r.Path == "-" {
+		if r.Stdin == nil {
+			return nil, errors.New("stdin not available")
+		}
+		return io.NopCloser(r.Stdin), nil
+	}
+	if r.Path != "" {
+		file, err := os.Open(r.Path)
+		if err != nil {
+			return nil, fmt.Errorf("opening input for day %d: %w", day, err)
+		}
+		return file, nil
+	}
+
+	var tried []string
+	candidates, err := r.candidates(day)
+	if err != nil {
+		return nil, err
+	}
+	for _, candidate := range candidates {
+		file, err := os.Open(candidate)
+		if err == nil {
+			return file, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("opening input for day %d: %w", day, err)
+		}
+		tried = append(tried, candidate)
+	}
+
+	if stdinIsPiped(r.Stdin) {
+		return io.NopCloser(r.Stdin), nil
+	}
+
+	return nil, fmt.Errorf(
+		"%w for day %d (tried %s, and stdin)",
+		ErrNotFound,
+		day,
+		strings.Join(tried, ", "),
+	)
+}
+
+// Returns the paths to look for the day's input file at, in order.
+func (r *Resolver) candidates(day int) ([]string, error) {
+	var candidates []string
+	if r.Dir != "" {
+		candidates = append(candidates, filepath.Join(r.Dir, DayDir(day), FileName))
+	}
+
+	workDir := r.WorkDir
+	if workDir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("finding working directory: %w", err)
+		}
+		workDir = wd
+	}
+	workDir, err := filepath.Abs(workDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Walk up from the working directory, so that both `day_N` itself and
+	// any directory containing `day_N` (e.g. the repository root) work.
+	for dir := workDir; ; dir = filepath.Dir(dir) {
+		if filepath.Base(dir) == DayDir(day) {
+			candidates = append(candidates, filepath.Join(dir, FileName))
+		} else {
+			candidates = append(candidates, filepath.Join(dir, DayDir(day), FileName))
+		}
+
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+
+	return candidates, nil
+}
+
+// Whether `stdin` can be read from without blocking on a terminal.
+func stdinIsPiped(stdin io.Reader) bool {
+	if stdin == nil {
+		return false
+	}
+	file, ok := stdin.(*os.File)
+	if !ok {
+		return true
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice == 0
+}
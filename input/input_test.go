@@ -0,0 +1,85 @@
+package input
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Writes `content` to dir/day_N/input.txt, creating the day directory.
+func writeInput(t *testing.T, dir string, day int, content string) {
+	t.Helper()
+	dayDir := filepath.Join(dir, DayDir(day))
+	if err := os.MkdirAll(dayDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dayDir, FileName), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readAll(t *testing.T, r *Resolver, day int) (string, error) {
+	t.Helper()
+	rc, err := r.Open(day)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b), nil
+}
+
+func TestResolver_Open(t *testing.T) {
+	envDir, repoDir := t.TempDir(), t.TempDir()
+	writeInput(t, envDir, 1, "from env dir")
+	writeInput(t, repoDir, 1, "from repo dir")
+	writeInput(t, repoDir, 2, "from repo dir")
+	explicit := filepath.Join(t.TempDir(), "explicit.txt")
+	if err := os.WriteFile(explicit, []byte("from path"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		resolver Resolver
+		day      int
+		want     string
+	}{
+		{"explicit path", Resolver{Path: explicit, Dir: envDir, WorkDir: repoDir}, 1, "from path"},
+		{"explicit stdin", Resolver{Path: "-", Dir: envDir, Stdin: strings.NewReader("from stdin")}, 1, "from stdin"},
+		{"env dir", Resolver{Dir: envDir, WorkDir: repoDir}, 1, "from env dir"},
+		{"env dir missing day", Resolver{Dir: envDir, WorkDir: repoDir}, 2, "from repo dir"},
+		{"repository root", Resolver{WorkDir: repoDir}, 1, "from repo dir"},
+		{"sibling directory", Resolver{WorkDir: filepath.Join(repoDir, DayDir(2))}, 1, "from repo dir"},
+		{"day directory", Resolver{WorkDir: filepath.Join(repoDir, DayDir(2))}, 2, "from repo dir"},
+		{"stdin fallback", Resolver{WorkDir: repoDir, Stdin: strings.NewReader("from stdin")}, 3, "from stdin"},
+	}
+
+	for _, test := range tests {
+		got, err := readAll(t, &test.resolver, test.day)
+		if err != nil {
+			t.Fatalf("[TestResolver_Open] %s: unexpected error '%s'", test.name, err.Error())
+		}
+		if got != test.want {
+			t.Fatalf("[TestResolver_Open] %s: expected %q, actual %q", test.name, test.want, got)
+		}
+	}
+}
+
+func TestResolver_Open_errors(t *testing.T) {
+	r := &Resolver{WorkDir: t.TempDir()}
+	if _, err := r.Open(1); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("[TestResolver_Open_errors] expected ErrNotFound, got %v", err)
+	}
+
+	r = &Resolver{Path: filepath.Join(t.TempDir(), "missing.txt")}
+	if _, err := r.Open(1); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("[TestResolver_Open_errors] expected ErrNotExist, got %v", err)
+	}
+}
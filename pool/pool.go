@@ -0,0 +1,159 @@
+// Package pool processes items concurrently on a bounded number of workers,
+// returning the results in input order.
+package pool
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+)
+
+// Error is returned when processing an item fails. Index is the position
+// of the failed item in the input.
+type Error struct {
+	Index int
+	Err   error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Map applies `fn` to every item of `items` using at most `workers`
+// goroutines (runtime.GOMAXPROCS(0) if `workers` is not positive), and
+// returns the results in the same order as `items`.
+//
+// Once an item fails no new items are started. Items are started in order,
+// so the error returned, an *Error, is always the one of the first failing
+// item, whatever order the workers finish in. If `ctx` is cancelled before
+// every item has been processed, its error is returned.
+func Map[In, Out any](ctx context.Context, workers int, items []In, fn func(In) (Out, error)) ([]Out, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(items))
+
+	stop, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]Out, len(items))
+	errs := make([]error, len(items))
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				results[i], errs[i] = fn(items[i])
+				if errs[i] != nil {
+					cancel()
+				}
+			}
+		}()
+	}
+
+	dispatched := 0
+dispatch:
+	for i := range items {
+		// select picks at random when both cases are ready
+		if stop.Err() != nil {
+			break
+		}
+		select {
+		case <-stop.Done():
+			break dispatch
+		case indices <- i:
+			dispatched++
+		}
+	}
+	close(indices)
+	wg.Wait()
+
+	for i, err := range errs[:dispatched] {
+		if err != nil {
+			return nil, &Error{Index: i, Err: err}
+		}
+	}
+	if dispatched < len(items) {
+		return nil, ctx.Err()
+	}
+	return results, nil
+}
+
+// BatchSize is the number of lines FoldLines reads at a time when not
+// given a positive batch size.
+const BatchSize = 4096
+
+// FoldLines reads `r` line by line, applies `fn` to every line as Map does,
+// and folds the results into `acc` with `fold`, in input order. Lines are
+// read and processed `batch` at a time (BatchSize if `batch` is not
+// positive), so memory use does not grow with the size of `r`. A failing
+// line is reported with its line number, starting at 1.
+func FoldLines[Out, Acc any](
+	ctx context.Context,
+	workers, batch int,
+	r io.Reader,
+	fn func(string) (Out, error),
+	acc Acc,
+	fold func(Acc, Out) Acc,
+) (Acc, error) {
+	if batch <= 0 {
+		batch = BatchSize
+	}
+	scanner := bufio.NewScanner(r)
+
+	lines := make([]string, 0, batch)
+	read := 0
+	flush := func() error {
+		results, err := Map(ctx, workers, lines, fn)
+		if perr, ok := err.(*Error); ok {
+			return fmt.Errorf("line %d: %w", read-len(lines)+perr.Index+1, perr.Err)
+		}
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			acc = fold(acc, result)
+		}
+		lines = lines[:0]
+		return nil
+	}
+
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+		read++
+		if len(lines) == batch {
+			if err := flush(); err != nil {
+				return acc, err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return acc, err
+	}
+	if err := flush(); err != nil {
+		return acc, err
+	}
+	return acc, nil
+}
+
+// Lines reads `r` line by line and applies `fn` to every line, as Map does.
+// A failing line is reported with its line number, starting at 1.
+func Lines[Out any](ctx context.Context, workers int, r io.Reader, fn func(string) (Out, error)) ([]Out, error) {
+	results, err := FoldLines(ctx, workers, 0, r, fn, []Out(nil), func(results []Out, result Out) []Out {
+		return append(results, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,174 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMap_order(t *testing.T) {
+	items := make([]int, 100)
+	for i := range items {
+		items[i] = i
+	}
+
+	// later items finish first
+	results, err := Map(context.Background(), 8, items, func(i int) (int, error) {
+		time.Sleep(time.Duration(len(items)-i) * 10 * time.Microsecond)
+		return i * i, nil
+	})
+	if err != nil {
+		t.Fatalf("[TestMap_order] unexpected error '%s'", err.Error())
+	}
+	for i, result := range results {
+		if result != i*i {
+			t.Fatalf("[TestMap_order] expected result %d to be %d, actual %d", i, i*i, result)
+		}
+	}
+}
+
+func TestMap_workers(t *testing.T) {
+	for _, workers := range []int{1, 3, 16} {
+		var running, maxRunning atomic.Int32
+		_, err := Map(context.Background(), workers, make([]int, 50), func(int) (int, error) {
+			curr := running.Add(1)
+			defer running.Add(-1)
+			for {
+				prev := maxRunning.Load()
+				if curr <= prev || maxRunning.CompareAndSwap(prev, curr) {
+					break
+				}
+			}
+			time.Sleep(100 * time.Microsecond)
+			return 0, nil
+		})
+		if err != nil {
+			t.Fatalf("[TestMap_workers] unexpected error '%s'", err.Error())
+		}
+		if maxRunning.Load() > int32(workers) {
+			t.Fatalf("[TestMap_workers] expected at most %d workers, actual %d", workers, maxRunning.Load())
+		}
+	}
+}
+
+func TestMap_error(t *testing.T) {
+	errOdd := errors.New("odd")
+	items := []int{0, 2, 4, 5, 6, 7, 8}
+
+	for i := 0; i < 20; i++ {
+		_, err := Map(context.Background(), 4, items, func(n int) (int, error) {
+			if n%2 == 1 {
+				// let the later failure finish first
+				if n == 5 {
+					time.Sleep(time.Millisecond)
+				}
+				return 0, errOdd
+			}
+			return n, nil
+		})
+
+		var perr *Error
+		if !errors.As(err, &perr) {
+			t.Fatalf("[TestMap_error] expected a *Error, actual %v", err)
+		}
+		if perr.Index != 3 {
+			t.Fatalf("[TestMap_error] expected the error of item 3, actual item %d", perr.Index)
+		}
+		if !errors.Is(err, errOdd) {
+			t.Fatalf("[TestMap_error] expected error to wrap %v", errOdd)
+		}
+	}
+}
+
+func TestMap_errorStopsDispatch(t *testing.T) {
+	var calls atomic.Int32
+	_, err := Map(context.Background(), 1, make([]int, 100), func(int) (int, error) {
+		calls.Add(1)
+		return 0, errors.New("fail")
+	})
+	if err == nil {
+		t.Fatalf("[TestMap_errorStopsDispatch] expected an error")
+	}
+	if calls.Load() > 2 {
+		t.Fatalf("[TestMap_errorStopsDispatch] expected no items to start after the failure, actual %d calls", calls.Load())
+	}
+}
+
+func TestMap_cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+	_, err := Map(ctx, 2, make([]int, 100), func(int) (int, error) {
+		if calls.Add(1) == 10 {
+			cancel()
+		}
+		return 0, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("[TestMap_cancel] expected %v, actual %v", context.Canceled, err)
+	}
+	if calls.Load() == 100 {
+		t.Fatalf("[TestMap_cancel] expected cancellation to stop processing")
+	}
+}
+
+func TestMap_empty(t *testing.T) {
+	results, err := Map(context.Background(), 4, []int{}, func(i int) (int, error) { return i, nil })
+	if err != nil || len(results) != 0 {
+		t.Fatalf("[TestMap_empty] expected no results and no error, actual %v, %v", results, err)
+	}
+}
+
+func TestLines(t *testing.T) {
+	doc := "1\n2\n3\n4"
+	results, err := Lines(context.Background(), 2, strings.NewReader(doc), strconv.Atoi)
+	if err != nil {
+		t.Fatalf("[TestLines] unexpected error '%s'", err.Error())
+	}
+	if !slices.Equal(results, []int{1, 2, 3, 4}) {
+		t.Fatalf("[TestLines] expected [1 2 3 4], actual %v", results)
+	}
+
+	_, err = Lines(context.Background(), 2, strings.NewReader("1\nx\n3"), strconv.Atoi)
+	expected := fmt.Sprintf("line 2: %v", mustAtoiErr("x"))
+	if err == nil || err.Error() != expected {
+		t.Fatalf("[TestLines] expected error %q, actual %v", expected, err)
+	}
+}
+
+func TestFoldLines(t *testing.T) {
+	var doc strings.Builder
+	for i := 1; i <= 1000; i++ {
+		fmt.Fprintln(&doc, i)
+	}
+
+	// concatenating shows the fold runs in input order across batches
+	for _, batch := range []int{1, 7, 1000, 5000} {
+		actual, err := FoldLines(context.Background(), 4, batch, strings.NewReader(doc.String()), strconv.Atoi, "",
+			func(acc string, n int) string { return acc + strconv.Itoa(n) + "\n" })
+		if err != nil {
+			t.Fatalf("[TestFoldLines] unexpected error '%s'", err.Error())
+		}
+		if actual != doc.String() {
+			t.Fatalf("[TestFoldLines] expected the lines folded in order with batch %d", batch)
+		}
+	}
+
+	// line numbers carry over between batches
+	_, err := FoldLines(context.Background(), 4, 3, strings.NewReader("1\n2\n3\n4\nx\n6"), strconv.Atoi, 0,
+		func(acc, n int) int { return acc + n })
+	expected := fmt.Sprintf("line 5: %v", mustAtoiErr("x"))
+	if err == nil || err.Error() != expected {
+		t.Fatalf("[TestFoldLines] expected error %q, actual %v", expected, err)
+	}
+}
+
+func mustAtoiErr(s string) error {
+	_, err := strconv.Atoi(s)
+	return err
+}
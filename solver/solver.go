@@ -0,0 +1,150 @@
+// Package solver defines the interface shared by the puzzle solvers of
+// every day.
+package solver
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Part selects which part(s) of a puzzle to solve.
+type Part int
+
+const (
+	// Both parts of the puzzle (the zero value).
+	Both Part = iota
+	One
+	Two
+)
+
+// ErrNotImplemented is returned when a part is requested that the day's
+// solver does not implement.
+var ErrNotImplemented = errors.New("part not implemented")
+
+func (p Part) String() string {
+	switch p {
+	case Both:
+		return "both"
+	case One:
+		return "1"
+	case Two:
+		return "2"
+	default:
+		return fmt.Sprintf("Part(%d)", int(p))
+	}
+}
+
+// Set parses a part from its flag value, so that a Part can be used with
+// flag.Var.
+func (p *Part) Set(s string) error {
+	switch s {
+	case "", "0", "both":
+		*p = Both
+	case "1":
+		*p = One
+	case "2":
+		*p = Two
+	default:
+		return fmt.Errorf("invalid part %q (want 1, 2 or both)", s)
+	}
+	return nil
+}
+
+// Includes reports whether selecting `p` should solve part `other`.
+func (p Part) Includes(other Part) bool {
+	return p == Both || p == other
+}
+
+// Stats holds intermediate values of interest computed while solving a
+// part, keyed by a snake_case name.
+type Stats map[string]any
+
+// Result is the answer to one part of a day's puzzle.
+type Result struct {
+	Day    int  `json:"day"`
+	Part   Part `json:"part"`
+	Answer int  `json:"answer"`
+	// Time taken to solve the part, excluding reading the input.
+	Duration time.Duration `json:"duration_ns"`
+	// Hex encoded SHA-256 hash of the input the part was solved for.
+	InputHash string `json:"input_hash"`
+	Stats     Stats  `json:"stats,omitempty"`
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf("day %d part %s: %d", r.Day, r.Part, r.Answer)
+}
+
+// Solver solves the puzzle of a single day.
+type Solver interface {
+	// Solve reads the puzzle input from `r` and returns the results of the
+	// requested part(s), in part order.
+	Solve(r io.Reader, part Part) ([]Result, error)
+}
+
+// PartFunc solves a single part of a puzzle from its input. Only the
+// Answer, and optionally the Stats, of the returned Result need to be set.
+type PartFunc func(r io.Reader) (Result, error)
+
+// Day is a Solver built from the functions solving each part of a day's
+// puzzle. A nil PartFunc marks that part as not implemented.
+type Day struct {
+	Number int
+	Part1  PartFunc
+	Part2  PartFunc
+}
+
+func (d *Day) partFunc(part Part) PartFunc {
+	switch part {
+	case One:
+		return d.Part1
+	case Two:
+		return d.Part2
+	default:
+		return nil
+	}
+}
+
+// Solve runs the requested part(s) of the day's puzzle. When both parts are
+// requested, parts that are not implemented are skipped; requesting a
+// single unimplemented part returns ErrNotImplemented.
+func (d *Day) Solve(r io.Reader, part Part) ([]Result, error) {
+	// Each part parses the input on its own, so buffer it to be read twice
+	input, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("day %d: reading input: %w", d.Number, err)
+	}
+	hash := sha256.Sum256(input)
+
+	var results []Result
+	for _, curr := range []Part{One, Two} {
+		if !part.Includes(curr) {
+			continue
+		}
+
+		fn := d.partFunc(curr)
+		if fn == nil {
+			if part == Both {
+				continue
+			}
+			return nil, fmt.Errorf("day %d part %s: %w", d.Number, curr, ErrNotImplemented)
+		}
+
+		start := time.Now()
+		result, err := fn(bytes.NewReader(input))
+		if err != nil {
+			return nil, fmt.Errorf("day %d part %s: %w", d.Number, curr, err)
+		}
+		result.Duration = time.Since(start)
+		result.Day, result.Part = d.Number, curr
+		result.InputHash = hex.EncodeToString(hash[:])
+		results = append(results, result)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,45 @@
+// Package solvertest runs solvers against puzzle examples in tests.
+package solvertest
+
+import (
+	"github.com/ubermensch/advent_of_code_2023/solver"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Example is a puzzle example input, stored under the package's testdata
+// directory, with the expected answer to one of its parts.
+type Example struct {
+	File string
+	Part solver.Part
+	Want int
+}
+
+// Run solves each example with `s`, failing the test for any error or
+// unexpected answer.
+func Run(t *testing.T, s solver.Solver, examples []Example) {
+	t.Helper()
+
+	for _, example := range examples {
+		name := example.File + "/part_" + example.Part.String()
+		t.Run(name, func(t *testing.T) {
+			file, err := os.Open(filepath.Join("testdata", example.File))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+
+			results, err := s.Solve(file, example.Part)
+			if err != nil {
+				t.Fatalf("unexpected error '%s'", err.Error())
+			}
+			if len(results) != 1 {
+				t.Fatalf("expected 1 result, actual %d", len(results))
+			}
+			if results[0].Answer != example.Want {
+				t.Fatalf("expected %d, actual %d", example.Want, results[0].Answer)
+			}
+		})
+	}
+}